@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"reminder-server/internal/initializers"
+	_ "reminder-server/migrations"
 	"strconv"
 
 	"github.com/pressly/goose/v3"
@@ -17,6 +18,8 @@ var (
 
 func main() {
 	initializers.LoadEnv()
+	// Go migrations seed data from the same set the server uses
+	initializers.LoadSeeds()
 	flag.Parse()
 	args := flag.Args()
 
@@ -9,6 +9,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	_ "reminder-server/docs" // swagger docs
+	_ "time/tzdata"          // user time zones must resolve without system zoneinfo
 )
 
 // @title           Reminder Server API
@@ -36,6 +37,8 @@ func main() {
 
 	router.SetupRouter(r, *in)
 
+	initializers.StartJobs()
+
 	// Swagger endpoint
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
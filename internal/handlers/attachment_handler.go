@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for the multipart envelope around the
+// uploaded file when limiting the request body size.
+const multipartOverhead = 64 << 10
+
+type AttachmentHandler struct {
+	service *services.AttachmentService
+}
+
+func NewAttachmentHandler(service *services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List attachments
+// @Description  Get the files attached to a reminder
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {array}   models.Attachment
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/attachments/ [get]
+func (h *AttachmentHandler) List(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachments, err := h.service.List(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// Upload godoc
+// @Summary      Upload an attachment
+// @Description  Attach a file to a reminder. The content type is detected from the file itself.
+// @Tags         attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     Bearer
+// @Param        id    path      int   true  "Reminder ID"
+// @Param        file  formData  file  true  "File to attach"
+// @Success      201   {object}  models.Attachment
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      413   {object}  map[string]string
+// @Failure      415   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /reminders/{id}/attachments/ [post]
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.service.MaxSize()+multipartOverhead)
+
+	reader, err := c.Request.MultipartReader()
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Stream the first "file" part straight to the service instead of letting
+	// the whole form be buffered
+	for {
+		part, err := reader.NextPart()
+
+		if err == io.EOF {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+
+		if err != nil {
+			c.JSON(uploadErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := h.service.Upload(c.Request.Context(), utils.GetUserID(c), int64(reminderID), part.FileName(), part)
+		part.Close()
+
+		if err != nil {
+			c.JSON(uploadErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, attachment)
+		return
+	}
+}
+
+// Download godoc
+// @Summary      Download an attachment
+// @Description  Stream the content of an attachment. Range requests are supported.
+// @Tags         attachments
+// @Produce      octet-stream
+// @Security     Bearer
+// @Param        id             path      int     true   "Reminder ID"
+// @Param        attachment_id  path      int     true   "Attachment ID"
+// @Param        Range          header    string  false  "Byte range to return"
+// @Success      200            {file}    file
+// @Success      206            {file}    file
+// @Failure      400            {object}  map[string]string
+// @Failure      404            {object}  map[string]string
+// @Failure      416            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
+// @Router       /reminders/{id}/attachments/{attachment_id} [get]
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(c.Param("attachment_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachment, blob, err := h.service.Open(c.Request.Context(), utils.GetUserID(c), int64(reminderID), int64(attachmentID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	defer blob.Close()
+
+	var modTime time.Time
+
+	if attachment.CreatedAt != nil {
+		modTime = *attachment.CreatedAt
+	}
+
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(c.Writer, c.Request, attachment.FileName, modTime, blob)
+}
+
+// Delete godoc
+// @Summary      Delete an attachment
+// @Description  Remove an attachment and its stored content
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id             path      int  true  "Reminder ID"
+// @Param        attachment_id  path      int  true  "Attachment ID"
+// @Success      204            {object}  nil
+// @Failure      400            {object}  map[string]string
+// @Failure      404            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
+// @Router       /reminders/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(c.Param("attachment_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), utils.GetUserID(c), int64(reminderID), int64(attachmentID)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// uploadErrorStatus reports a body over the size limit as 413 and defers to
+// errorStatus for everything else.
+func uploadErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return errorStatus(err)
+}
@@ -30,6 +30,7 @@ func NewCategoryHandler(categoryService *services.CategoryService) *CategoryHand
 // @Param        category  body      models.CategoryCreateRequest  true  "Category data"
 // @Success      201       {object}  models.Category
 // @Failure      400       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /categories/ [post]
 func (h *CategoryHandler) Create(c *gin.Context) {
@@ -39,32 +40,53 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 		return
 	}
 
-	category, err := h.categoryService.Create(req)
+	category, err := h.categoryService.Create(utils.GetUserID(c), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
+	c.Header("ETag", etag(category.Version))
 	c.JSON(http.StatusCreated, category)
 }
 
 // List godoc
 // @Summary      List all categories
-// @Description  Get all categories for the authenticated user
+// @Description  Get all categories for the authenticated user, either as a flat list or as a tree of top-level categories with their children
 // @Tags         categories
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Success      200  {array}   models.Category
-// @Failure      500  {object}  map[string]string
+// @Param        tree           query     bool    false  "Nest subcategories under their parent"
+// @Param        If-None-Match  header    string  false  "ETag of a previous response"
+// @Success      200            {array}   models.Category
+// @Success      304            {object}  nil
+// @Failure      500            {object}  map[string]string
 // @Router       /categories/ [get]
 func (h *CategoryHandler) List(c *gin.Context) {
-	categories, err := h.categoryService.List(1)
+	list := h.categoryService.List
+
+	if c.Query("tree") == "true" {
+		list = h.categoryService.Tree
+	}
+
+	categories, err := list(utils.GetUserID(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	tag, err := listETag(categories)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if notModified(c, tag) {
+		return
+	}
+
 	c.JSON(http.StatusOK, categories)
 }
 
@@ -75,10 +97,13 @@ func (h *CategoryHandler) List(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Param        id   path      int  true  "Category ID"
-// @Success      200  {object}  models.Category
-// @Failure      400  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Param        id             path      int     true   "Category ID"
+// @Param        If-None-Match  header    string  false  "ETag of a previous response"
+// @Success      200            {object}  models.Category
+// @Success      304            {object}  nil
+// @Failure      400            {object}  map[string]string
+// @Failure      404            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
 // @Router       /categories/{id} [get]
 func (h *CategoryHandler) Get(c *gin.Context) {
 	categoryID, err := strconv.Atoi(c.Param("id"))
@@ -88,9 +113,13 @@ func (h *CategoryHandler) Get(c *gin.Context) {
 		return
 	}
 
-	category, err := h.categoryService.Get(int64(categoryID))
+	category, err := h.categoryService.GetForUser(utils.GetUserID(c), int64(categoryID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": utils.ErrorSqlNoRows(err).Error()})
+		c.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	if notModified(c, etag(category.Version)) {
 		return
 	}
 
@@ -104,10 +133,15 @@ func (h *CategoryHandler) Get(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Param        id        path      int                           true  "Category ID"
-// @Param        category  body      models.CategoryUpdateRequest  true  "Category update data"
+// @Param        id        path      int                           true   "Category ID"
+// @Param        category  body      models.CategoryUpdateRequest  true   "Category update data"
+// @Param        If-Match  header    string                        false  "ETag the change is based on"
 // @Success      200       {object}  models.Category
 // @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      412       {object}  map[string]string
+// @Failure      428       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /categories/{id} [patch]
 func (h *CategoryHandler) Update(c *gin.Context) {
@@ -124,26 +158,132 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 		return
 	}
 
-	category, err := h.categoryService.Update(int64(categoryID), req)
+	if h.preconditionFailed(c, int64(categoryID)) {
+		return
+	}
+
+	category, err := h.versioned(c).Update(utils.GetUserID(c), int64(categoryID), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	c.Header("ETag", etag(category.Version))
+	c.JSON(http.StatusOK, category)
+}
+
+// Move godoc
+// @Summary      Move a category
+// @Description  Place a category and its subcategories under another category, or at the top level when parent_id is null
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id        path      int                         true   "Category ID"
+// @Param        move      body      models.CategoryMoveRequest  true   "New parent of the category"
+// @Param        If-Match  header    string                      false  "ETag the move is based on"
+// @Success      200       {object}  models.Category
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      412       {object}  map[string]string
+// @Failure      428       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /categories/{id}/move [post]
+func (h *CategoryHandler) Move(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.CategoryMoveRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if h.preconditionFailed(c, int64(categoryID)) {
+		return
+	}
+
+	category, err := h.versioned(c).Move(utils.GetUserID(c), int64(categoryID), req)
+	if err != nil {
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
+	c.Header("ETag", etag(category.Version))
 	c.JSON(http.StatusOK, category)
 }
 
+// Merge godoc
+// @Summary      Merge categories
+// @Description  Move the reminders, templates, subcategories and saved filters of the source categories into this one and delete the sources
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id        path      int                          true   "Category ID"
+// @Param        merge     body      models.CategoryMergeRequest  true   "Categories merged into this one"
+// @Param        If-Match  header    string                       false  "ETag the merge is based on"
+// @Success      200       {object}  models.CategoryMergeResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      412       {object}  map[string]string
+// @Failure      428       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /categories/{id}/merge [post]
+func (h *CategoryHandler) Merge(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.CategoryMergeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if h.preconditionFailed(c, int64(categoryID)) {
+		return
+	}
+
+	response, err := h.versioned(c).Merge(utils.GetUserID(c), int64(categoryID), req)
+	if err != nil {
+		c.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	c.Header("ETag", etag(response.Category.Version))
+	c.JSON(http.StatusOK, response)
+}
+
 // Delete godoc
 // @Summary      Delete a category
-// @Description  Delete a category by ID
+// @Description  Delete a category by ID. A category still in use is only deleted when its reminders, templates and subcategories are reassigned to target or cascade with it
 // @Tags         categories
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Param        id   path      int  true  "Category ID"
-// @Success      204  {object}  nil
-// @Failure      400  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Param        id        path      int     true   "Category ID"
+// @Param        strategy  query     string  false  "reject (default), reassign or cascade"
+// @Param        target    query     int     false  "Category the reminders are moved to on reassign"
+// @Param        If-Match  header    string  false  "ETag the deletion is based on"
+// @Success      204       {object}  nil
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      412       {object}  map[string]string
+// @Failure      428       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
 // @Router       /categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *gin.Context) {
 	categoryID, err := strconv.Atoi(c.Param("id"))
@@ -153,10 +293,43 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.categoryService.Delete(int64(categoryID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var query models.CategoryDeleteQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if h.preconditionFailed(c, int64(categoryID)) {
+		return
+	}
+
+	if err := h.versioned(c).Delete(utils.GetUserID(c), int64(categoryID), query); err != nil {
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// versioned returns the service held to the version the request's If-Match
+// names, so it's checked again where the change is saved.
+func (h *CategoryHandler) versioned(c *gin.Context) *services.CategoryService {
+	if version, ok := ifMatchVersion(c); ok {
+		return h.categoryService.IfVersion(version)
+	}
+
+	return h.categoryService
+}
+
+// preconditionFailed checks the request's If-Match against the category's
+// current version. A missing category is left for the action to report.
+func (h *CategoryHandler) preconditionFailed(c *gin.Context, categoryID int64) bool {
+	category, err := h.categoryService.GetForUser(utils.GetUserID(c), categoryID)
+
+	if err != nil {
+		return false
+	}
+
+	return preconditionFailed(c, category.Version)
+}
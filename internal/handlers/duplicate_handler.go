@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicateHandler struct {
+	service *services.DuplicateService
+}
+
+func NewDuplicateHandler(service *services.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{
+		service: service,
+	}
+}
+
+// DuplicateReminder godoc
+// @Summary      Duplicate a reminder
+// @Description  Copy a reminder with its checklist items, tags and attachments, optionally into another category or with shifted dates. The copy is pending with every item unchecked.
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id         path      int                              true   "Reminder ID"
+// @Param        duplicate  body      models.ReminderDuplicateRequest  false  "Duplicate options"
+// @Success      201        {object}  models.Reminder
+// @Failure      400        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /reminders/{id}/duplicate [post]
+func (h *DuplicateHandler) DuplicateReminder(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderDuplicateRequest
+
+	// The body is optional
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder, err := h.service.DuplicateReminder(utils.GetUserID(c), int64(reminderID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(reminder.Version))
+	c.JSON(http.StatusCreated, reminder)
+}
+
+// DuplicateCategory godoc
+// @Summary      Duplicate a category
+// @Description  Copy a category together with all of its reminders that aren't archived
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id         path      int                              true   "Category ID"
+// @Param        duplicate  body      models.CategoryDuplicateRequest  false  "Duplicate options"
+// @Success      201        {object}  models.Category
+// @Failure      400        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /categories/{id}/duplicate [post]
+func (h *DuplicateHandler) DuplicateCategory(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.CategoryDuplicateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.service.DuplicateCategory(utils.GetUserID(c), int64(categoryID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(category.Version))
+	c.JSON(http.StatusCreated, category)
+}
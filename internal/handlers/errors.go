@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorStatuses maps service error messages to the HTTP status they are
+// reported with. Anything not listed is treated as an internal error.
+var errorStatuses = map[string]int{
+	utils.ErrorReminderNotFound:      http.StatusNotFound,
+	utils.ErrorCategoryNotFound:      http.StatusNotFound,
+	utils.ErrorItemNotFound:          http.StatusNotFound,
+	utils.ErrorSavedFilterNotFound:   http.StatusNotFound,
+	utils.ErrorTemplateNotFound:      http.StatusNotFound,
+	utils.ErrorTagNotFound:           http.StatusNotFound,
+	utils.ErrorTagAlreadyExists:      http.StatusConflict,
+	utils.ErrorNoteNotFound:          http.StatusNotFound,
+	utils.ErrorNoteNotAuthor:         http.StatusForbidden,
+	utils.ErrorInvalidInclude:        http.StatusBadRequest,
+	utils.ErrorAttachmentNotFound:    http.StatusNotFound,
+	utils.ErrorAttachmentTooLarge:    http.StatusRequestEntityTooLarge,
+	utils.ErrorAttachmentEmpty:       http.StatusBadRequest,
+	utils.ErrorAttachmentType:        http.StatusUnsupportedMediaType,
+	utils.ErrorAttachmentQuota:       http.StatusRequestEntityTooLarge,
+	utils.ErrorInvalidFilter:         http.StatusBadRequest,
+	utils.ErrorInvalidItemOrder:      http.StatusBadRequest,
+	utils.ErrorInvalidBulkRequest:    http.StatusBadRequest,
+	utils.ErrorInvalidPriority:       http.StatusBadRequest,
+	utils.ErrorQuickAddNoTitle:       http.StatusBadRequest,
+	utils.ErrorUserNotFound:          http.StatusNotFound,
+	utils.ErrorReminderBlocked:       http.StatusConflict,
+	utils.ErrorDependencyCycle:       http.StatusConflict,
+	utils.ErrorDependencyExists:      http.StatusConflict,
+	utils.ErrorDependencyNotFound:    http.StatusNotFound,
+	utils.ErrorInvalidStatus:         http.StatusBadRequest,
+	utils.ErrorRevisionNotFound:      http.StatusNotFound,
+	utils.ErrorRevisionNotRevertible: http.StatusConflict,
+	utils.ErrorNothingToUndo:         http.StatusConflict,
+	utils.ErrorVersionConflict:       http.StatusPreconditionFailed,
+	utils.ErrorInvalidSyncToken:      http.StatusBadRequest,
+	utils.ErrorInvalidMove:           http.StatusBadRequest,
+	utils.ErrorStartAfterDue:         http.StatusBadRequest,
+	utils.ErrorTimeEntryNotFound:     http.StatusNotFound,
+	utils.ErrorTimerAlreadyRunning:   http.StatusConflict,
+	utils.ErrorTimerNotRunning:       http.StatusConflict,
+	utils.ErrorInvalidTimeEntry:      http.StatusBadRequest,
+	utils.ErrorCategoryNameRequired:  http.StatusBadRequest,
+	utils.ErrorCategoryNameTaken:     http.StatusConflict,
+	utils.ErrorInvalidCategoryColor:  http.StatusBadRequest,
+	utils.ErrorInvalidCategoryIcon:   http.StatusBadRequest,
+	utils.ErrorCategoryInUse:         http.StatusConflict,
+	utils.ErrorInvalidReassignTarget: http.StatusBadRequest,
+	utils.ErrorInvalidCategoryParent: http.StatusBadRequest,
+	utils.ErrorCategoryCycle:         http.StatusConflict,
+	utils.ErrorCategoryTooDeep:       http.StatusBadRequest,
+	utils.ErrorDueDateRequired:       http.StatusBadRequest,
+	utils.ErrorInvalidDueTime:        http.StatusBadRequest,
+	utils.ErrorInvalidMergeSource:    http.StatusBadRequest,
+}
+
+// errorFields names the request field a validation error is about, so
+// clients can show it next to the right input.
+var errorFields = map[string]string{
+	utils.ErrorCategoryNameRequired:  "name",
+	utils.ErrorCategoryNameTaken:     "name",
+	utils.ErrorInvalidCategoryColor:  "color",
+	utils.ErrorInvalidCategoryIcon:   "icon",
+	utils.ErrorInvalidReassignTarget: "target",
+	utils.ErrorInvalidCategoryParent: "parent_id",
+	utils.ErrorCategoryCycle:         "parent_id",
+	utils.ErrorCategoryTooDeep:       "parent_id",
+	utils.ErrorDueDateRequired:       "due_date",
+	utils.ErrorInvalidDueTime:        "default_due_time",
+	utils.ErrorInvalidMergeSource:    "source_ids",
+}
+
+func errorStatus(err error) int {
+	if status, ok := errorStatuses[err.Error()]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// errorResponse is the body an error is reported with. Errors about a single
+// request field also name that field.
+func errorResponse(err error) gin.H {
+	body := gin.H{"error": err.Error()}
+
+	if field, ok := errorFields[err.Error()]; ok {
+		body["field"] = field
+	}
+
+	return body
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"reminder-server/internal/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag is the entity tag of a single resource, derived from its version.
+func etag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// listETag is a weak tag over a whole list response. It hashes the encoded
+// body, so it also changes with derived fields like progress that don't bump
+// any version.
+func listETag(body any) (string, error) {
+	encoded, err := json.Marshal(body)
+
+	if err != nil {
+		return "", err
+	}
+
+	hash := fnv.New64a()
+	hash.Write(encoded)
+
+	return fmt.Sprintf(`W/"%x"`, hash.Sum64()), nil
+}
+
+// notModified sets the ETag header and answers 304 when the client's
+// If-None-Match already holds the tag. Handlers stop when it returns true.
+func notModified(c *gin.Context, tag string) bool {
+	c.Header("ETag", tag)
+
+	if !etagMatches(c.GetHeader("If-None-Match"), tag, true) {
+		return false
+	}
+
+	c.Status(http.StatusNotModified)
+	c.Writer.WriteHeaderNow()
+
+	return true
+}
+
+// preconditionFailed checks the request's If-Match against the resource's
+// current tag, answering 412 on a mismatch. A missing header is accepted
+// unless REQUIRE_IF_MATCH is set, in which case it's answered with 428.
+// Handlers stop when it returns true.
+func preconditionFailed(c *gin.Context, version int64) bool {
+	header := c.GetHeader("If-Match")
+
+	if header == "" {
+		if utils.GetEnvInt64("REQUIRE_IF_MATCH", 0) == 0 {
+			return false
+		}
+
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": utils.ErrorPreconditionRequired})
+		return true
+	}
+
+	if etagMatches(header, etag(version), false) {
+		return false
+	}
+
+	c.Header("ETag", etag(version))
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": utils.ErrorVersionConflict})
+
+	return true
+}
+
+// ifMatchVersion returns the version named by an If-Match header holding a
+// single tag, for the service to check again where it saves the change.
+func ifMatchVersion(c *gin.Context) (int64, bool) {
+	version, err := strconv.ParseInt(strings.Trim(c.GetHeader("If-Match"), `"`), 10, 64)
+
+	return version, err == nil
+}
+
+// etagMatches reports whether a comma separated If-Match or If-None-Match
+// header holds the tag. If-None-Match compares weakly, ignoring W/ prefixes.
+func etagMatches(header string, tag string, weak bool) bool {
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" {
+			return true
+		}
+
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+
+		if candidate == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuickAddHandler struct {
+	service *services.QuickAddService
+}
+
+func NewQuickAddHandler(service *services.QuickAddService) *QuickAddHandler {
+	return &QuickAddHandler{
+		service: service,
+	}
+}
+
+// Add godoc
+// @Summary      Quick add a reminder
+// @Description  Create a reminder from a line of text such as "Pay rent every 1st of the month at 9am #Personal !high". Dates are read in the user's time zone.
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      models.QuickAddRequest  true   "Quick add text"
+// @Param        dry_run  query     bool                    false  "Only return how the text was parsed"
+// @Success      200      {object}  models.QuickAddResponse
+// @Success      201      {object}  models.QuickAddResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /reminders/quick [post]
+func (h *QuickAddHandler) Add(c *gin.Context) {
+	var req models.QuickAddRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	response, err := h.service.Add(utils.GetUserID(c), req, dryRun)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if dryRun {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
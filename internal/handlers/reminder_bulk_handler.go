@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderBulkHandler struct {
+	service *services.ReminderBulkService
+}
+
+func NewReminderBulkHandler(service *services.ReminderBulkService) *ReminderBulkHandler {
+	return &ReminderBulkHandler{
+		service: service,
+	}
+}
+
+// Apply godoc
+// @Summary      Apply an action to several reminders
+// @Description  Complete, reopen, delete, move, reprioritise, reschedule or retag reminders picked by ID or filter. Either every reminder is changed or none is.
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request  body      models.ReminderBulkRequest  true  "Bulk action"
+// @Success      200      {object}  models.ReminderBulkResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      422      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]string
+// @Router       /reminders/bulk [post]
+func (h *ReminderBulkHandler) Apply(c *gin.Context) {
+	var req models.ReminderBulkRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.Apply(utils.GetUserID(c), req)
+
+	if err != nil && err.Error() == utils.ErrorBulkActionFailed {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "results": response.Results})
+		return
+	}
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderDependencyHandler struct {
+	service *services.ReminderDependencyService
+}
+
+func NewReminderDependencyHandler(service *services.ReminderDependencyService) *ReminderDependencyHandler {
+	return &ReminderDependencyHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List the dependencies of a reminder
+// @Description  Get the reminders blocking a reminder and the reminders it blocks
+// @Tags         reminder-dependencies
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {object}  models.ReminderDependencies
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/dependencies [get]
+func (h *ReminderDependencyHandler) List(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dependencies, err := h.service.List(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dependencies)
+}
+
+// Add godoc
+// @Summary      Add a dependency
+// @Description  Block a reminder until another reminder is completed
+// @Tags         reminder-dependencies
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id          path      int                                     true  "Reminder ID"
+// @Param        dependency  body      models.ReminderDependencyCreateRequest  true  "Blocking reminder"
+// @Success      201         {object}  models.Reminder
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      409         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /reminders/{id}/dependencies [post]
+func (h *ReminderDependencyHandler) Add(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderDependencyCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder, err := h.service.Add(utils.GetUserID(c), int64(reminderID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reminder)
+}
+
+// Remove godoc
+// @Summary      Remove a dependency
+// @Description  Stop a reminder from waiting on another reminder
+// @Tags         reminder-dependencies
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id             path      int  true  "Reminder ID"
+// @Param        blocked_by_id  path      int  true  "Blocking reminder ID"
+// @Success      204            {object}  nil
+// @Failure      400            {object}  map[string]string
+// @Failure      404            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
+// @Router       /reminders/{id}/dependencies/{blocked_by_id} [delete]
+func (h *ReminderDependencyHandler) Remove(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	blockedByID, err := strconv.Atoi(c.Param("blocked_by_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Remove(utils.GetUserID(c), int64(reminderID), int64(blockedByID)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"reminder-server/internal/models"
 	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -26,21 +28,70 @@ func NewReminderHandler(service *services.ReminderService) *ReminderHandler {
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Success      200  {array}   models.Reminder
-// @Failure      500  {object}  map[string]string
+// @Param        filter         query     models.ReminderFilter  false  "Reminder filters"
+// @Param        If-None-Match  header    string                 false  "ETag of a previous response"
+// @Success      200            {array}   models.Reminder
+// @Success      304            {object}  nil
+// @Failure      400            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
 // @Router       /reminders/ [get]
 func (h *ReminderHandler) List(c *gin.Context) {
-	// TODO: Take the user id from the authenticated user
-	userID := c.GetInt64("user_id")
+	var filter models.ReminderFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithList(c, filter)
+}
+
+// ListFlagged godoc
+// @Summary      List flagged reminders
+// @Description  Get the flagged reminders of the authenticated user, pinned ones first. Accepts the same filters as the reminder list.
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        filter         query     models.ReminderFilter  false  "Reminder filters"
+// @Param        If-None-Match  header    string                 false  "ETag of a previous response"
+// @Success      200            {array}   models.Reminder
+// @Success      304            {object}  nil
+// @Failure      400            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
+// @Router       /reminders/views/flagged [get]
+func (h *ReminderHandler) ListFlagged(c *gin.Context) {
+	var filter models.ReminderFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flagged := true
+	filter.Flagged = &flagged
+
+	h.respondWithList(c, filter)
+}
+
+func (h *ReminderHandler) respondWithList(c *gin.Context, filter models.ReminderFilter) {
+	userID := utils.GetUserID(c)
+
+	reminders, err := h.service.List(int32(userID), filter)
 
-	if userID == 0 {
-		userID = 1 // Temporary hardcoded user ID for testing
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
 	}
 
-	reminders, err := h.service.List(int32(userID))
+	tag, err := listETag(reminders)
 
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if notModified(c, tag) {
 		return
 	}
 
@@ -54,9 +105,13 @@ func (h *ReminderHandler) List(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Param        id   path      int  true  "Reminder ID"
-// @Success      200  {object}  models.Reminder
-// @Failure      500  {object}  map[string]string
+// @Param        id             path      int     true   "Reminder ID"
+// @Param        include        query     string  false  "Comma separated related data to include (notes)"
+// @Param        If-None-Match  header    string  false  "ETag of a previous response"
+// @Success      200            {object}  models.Reminder
+// @Success      304            {object}  nil
+// @Failure      400            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
 // @Router       /reminders/{id} [get]
 func (h *ReminderHandler) Get(c *gin.Context) {
 	reminderID, err := strconv.Atoi(c.Param("id"))
@@ -68,6 +123,17 @@ func (h *ReminderHandler) Get(c *gin.Context) {
 		return
 	}
 
+	if include := c.Query("include"); include != "" {
+		if err := h.service.LoadIncludes(&reminder, strings.Split(include, ",")); err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if notModified(c, etag(reminder.Version)) {
+		return
+	}
+
 	c.JSON(http.StatusOK, reminder)
 }
 
@@ -91,13 +157,14 @@ func (h *ReminderHandler) Create(c *gin.Context) {
 		return
 	}
 
-	reminder, err := h.service.Create(req)
+	reminder, err := h.service.Create(utils.GetUserID(c), req)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
+	c.Header("ETag", etag(reminder.Version))
 	c.JSON(http.StatusCreated, reminder)
 }
 
@@ -108,10 +175,13 @@ func (h *ReminderHandler) Create(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Param        id        path      int                           true  "Reminder ID"
-// @Param        reminder  body      models.ReminderUpdateRequest  true  "Reminder update data"
+// @Param        id        path      int                           true   "Reminder ID"
+// @Param        reminder  body      models.ReminderUpdateRequest  true   "Reminder update data"
+// @Param        If-Match  header    string                        false  "ETag the change is based on"
 // @Success      200       {object}  models.Reminder
 // @Failure      400       {object}  map[string]string
+// @Failure      412       {object}  map[string]string
+// @Failure      428       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /reminders/{id} [patch]
 func (h *ReminderHandler) Update(c *gin.Context) {
@@ -124,13 +194,18 @@ func (h *ReminderHandler) Update(c *gin.Context) {
 		return
 	}
 
-	reminder, err := h.service.Update(int64(reminderID), req)
+	if h.preconditionFailed(c, int64(reminderID)) {
+		return
+	}
+
+	reminder, err := h.versioned(c).Update(int64(reminderID), req)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
+	c.Header("ETag", etag(reminder.Version))
 	c.JSON(http.StatusOK, reminder)
 }
 
@@ -141,17 +216,25 @@ func (h *ReminderHandler) Update(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Param        id   path      int  true  "Reminder ID"
-// @Success      204  {object}  nil
-// @Failure      500  {object}  map[string]string
+// @Param        id        path      int     true   "Reminder ID"
+// @Param        If-Match  header    string  false  "ETag the deletion is based on"
+// @Success      204       {object}  nil
+// @Failure      404       {object}  map[string]string
+// @Failure      412       {object}  map[string]string
+// @Failure      428       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
 // @Router       /reminders/{id} [delete]
 func (h *ReminderHandler) Delete(c *gin.Context) {
 	reminderID, err := strconv.Atoi(c.Param("id"))
 
-	err = h.service.Delete(int64(reminderID))
+	if h.preconditionFailed(c, int64(reminderID)) {
+		return
+	}
+
+	err = h.versioned(c).Delete(int64(reminderID))
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -160,22 +243,27 @@ func (h *ReminderHandler) Delete(c *gin.Context) {
 
 // UpdateStatus godoc
 // @Summary      Update reminder status
-// @Description  Update the status of a reminder (pending, completed, cancelled)
+// @Description  Update the status of a reminder (pending, completed). Completing a blocked reminder needs force.
 // @Tags         reminders
 // @Accept       json
 // @Produce      json
 // @Security     Bearer
-// @Param        id      path      int                       true  "Reminder ID"
-// @Param        status  body      object{status=string}     true  "Status data"
-// @Success      200     {object}  models.Reminder
-// @Failure      400     {object}  map[string]string
-// @Failure      500     {object}  map[string]string
+// @Param        id        path      int                               true   "Reminder ID"
+// @Param        status    body      object{status=string,force=bool}  true   "Status data"
+// @Param        If-Match  header    string                            false  "ETag the change is based on"
+// @Success      200       {object}  models.Reminder
+// @Failure      400       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      412       {object}  map[string]string
+// @Failure      428       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
 // @Router       /reminders/{id}/status [put]
 func (h *ReminderHandler) UpdateStatus(c *gin.Context) {
 	reminderID, err := strconv.Atoi(c.Param("id"))
 
 	var req struct {
 		Status string `json:"status" binding:"required"`
+		Force  bool   `json:"force"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -183,12 +271,147 @@ func (h *ReminderHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	reminder, err := h.service.UpdateStatus(int64(reminderID), req.Status)
+	updateStatus := h.versioned(c).UpdateStatus
+
+	if req.Force {
+		updateStatus = h.versioned(c).ForceUpdateStatus
+	}
+
+	if h.preconditionFailed(c, int64(reminderID)) {
+		return
+	}
+
+	reminder, err := updateStatus(int64(reminderID), req.Status)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
+	c.Header("ETag", etag(reminder.Version))
 	c.JSON(http.StatusOK, reminder)
 }
+
+// Archive godoc
+// @Summary      Archive a reminder
+// @Description  Hide a reminder from the default reminder list
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {object}  models.Reminder
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/archive [post]
+func (h *ReminderHandler) Archive(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder, err := h.service.Archive(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminder)
+}
+
+// Unarchive godoc
+// @Summary      Unarchive a reminder
+// @Description  Bring an archived reminder back to the default reminder list
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {object}  models.Reminder
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/unarchive [post]
+func (h *ReminderHandler) Unarchive(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder, err := h.service.Unarchive(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminder)
+}
+
+// Move godoc
+// @Summary      Move a reminder
+// @Description  Place a reminder right before or after another reminder, taking over its category, or at the end of a category. Use sort=manual to list reminders in this order.
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id    path      int                         true  "Reminder ID"
+// @Param        move  body      models.ReminderMoveRequest  true  "Where to move the reminder"
+// @Success      200   {object}  models.Reminder
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /reminders/{id}/move [post]
+func (h *ReminderHandler) Move(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderMoveRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder, err := h.service.Move(utils.GetUserID(c), int64(reminderID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(reminder.Version))
+	c.JSON(http.StatusOK, reminder)
+}
+
+// versioned returns the service held to the version the request's If-Match
+// names, so it's checked again where the change is saved.
+func (h *ReminderHandler) versioned(c *gin.Context) *services.ReminderService {
+	if version, ok := ifMatchVersion(c); ok {
+		return h.service.IfVersion(version)
+	}
+
+	return h.service
+}
+
+// preconditionFailed checks the request's If-Match against the reminder's
+// current version. A missing reminder is left for the action to report.
+func (h *ReminderHandler) preconditionFailed(c *gin.Context, reminderID int64) bool {
+	reminder, err := h.service.Get(reminderID)
+
+	if err != nil {
+		return false
+	}
+
+	return preconditionFailed(c, reminder.Version)
+}
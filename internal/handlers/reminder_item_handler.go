@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderItemHandler struct {
+	service *services.ReminderItemService
+}
+
+func NewReminderItemHandler(service *services.ReminderItemService) *ReminderItemHandler {
+	return &ReminderItemHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List checklist items
+// @Description  Get the checklist items of a reminder in order
+// @Tags         reminder-items
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {array}   models.ReminderItem
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/items/ [get]
+func (h *ReminderItemHandler) List(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.service.List(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// Create godoc
+// @Summary      Add a checklist item
+// @Description  Add a checklist item to a reminder, appended unless a position is given
+// @Tags         reminder-items
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id    path      int                               true  "Reminder ID"
+// @Param        item  body      models.ReminderItemCreateRequest  true  "Item data"
+// @Success      201   {object}  models.ReminderItem
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /reminders/{id}/items/ [post]
+func (h *ReminderItemHandler) Create(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderItemCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.service.Create(utils.GetUserID(c), int64(reminderID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// Update godoc
+// @Summary      Update a checklist item
+// @Description  Change the text of a checklist item or tick it off
+// @Tags         reminder-items
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                               true  "Reminder ID"
+// @Param        item_id  path      int                               true  "Item ID"
+// @Param        item     body      models.ReminderItemUpdateRequest  true  "Item update data"
+// @Success      200      {object}  models.ReminderItem
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /reminders/{id}/items/{item_id} [patch]
+func (h *ReminderItemHandler) Update(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderItemUpdateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.service.Update(utils.GetUserID(c), int64(reminderID), int64(itemID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// Delete godoc
+// @Summary      Delete a checklist item
+// @Description  Remove a checklist item from a reminder
+// @Tags         reminder-items
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int  true  "Reminder ID"
+// @Param        item_id  path      int  true  "Item ID"
+// @Success      204      {object}  nil
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /reminders/{id}/items/{item_id} [delete]
+func (h *ReminderItemHandler) Delete(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Delete(utils.GetUserID(c), int64(reminderID), int64(itemID)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Reorder godoc
+// @Summary      Reorder checklist items
+// @Description  Set the order of all checklist items of a reminder
+// @Tags         reminder-items
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id     path      int                                true  "Reminder ID"
+// @Param        order  body      models.ReminderItemReorderRequest  true  "Item IDs in their new order"
+// @Success      200    {array}   models.ReminderItem
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /reminders/{id}/items/order [put]
+func (h *ReminderItemHandler) Reorder(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderItemReorderRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.service.Reorder(utils.GetUserID(c), int64(reminderID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderNoteHandler struct {
+	service *services.ReminderNoteService
+}
+
+func NewReminderNoteHandler(service *services.ReminderNoteService) *ReminderNoteHandler {
+	return &ReminderNoteHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List notes
+// @Description  Get the notes left on a reminder, oldest first
+// @Tags         reminder-notes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {array}   models.ReminderNote
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/notes/ [get]
+func (h *ReminderNoteHandler) List(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	notes, err := h.service.List(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+// Create godoc
+// @Summary      Add a note
+// @Description  Leave a Markdown note on a reminder
+// @Tags         reminder-notes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id    path      int                               true  "Reminder ID"
+// @Param        note  body      models.ReminderNoteCreateRequest  true  "Note data"
+// @Success      201   {object}  models.ReminderNote
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /reminders/{id}/notes/ [post]
+func (h *ReminderNoteHandler) Create(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderNoteCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := h.service.Create(utils.GetUserID(c), int64(reminderID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// Update godoc
+// @Summary      Edit a note
+// @Description  Replace the body of a note, keeping the previous body in its history
+// @Tags         reminder-notes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                               true  "Reminder ID"
+// @Param        note_id  path      int                               true  "Note ID"
+// @Param        note     body      models.ReminderNoteUpdateRequest  true  "Note update data"
+// @Success      200      {object}  models.ReminderNote
+// @Failure      400      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /reminders/{id}/notes/{note_id} [patch]
+func (h *ReminderNoteHandler) Update(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	noteID, err := strconv.Atoi(c.Param("note_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderNoteUpdateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := h.service.Update(utils.GetUserID(c), int64(reminderID), int64(noteID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// Delete godoc
+// @Summary      Delete a note
+// @Description  Delete a note and its edit history
+// @Tags         reminder-notes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int  true  "Reminder ID"
+// @Param        note_id  path      int  true  "Note ID"
+// @Success      204      {object}  nil
+// @Failure      400      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /reminders/{id}/notes/{note_id} [delete]
+func (h *ReminderNoteHandler) Delete(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	noteID, err := strconv.Atoi(c.Param("note_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Delete(utils.GetUserID(c), int64(reminderID), int64(noteID)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// History godoc
+// @Summary      Get the edit history of a note
+// @Description  Get a note with its previous bodies, newest first
+// @Tags         reminder-notes
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int  true  "Reminder ID"
+// @Param        note_id  path      int  true  "Note ID"
+// @Success      200      {object}  models.ReminderNote
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /reminders/{id}/notes/{note_id}/history [get]
+func (h *ReminderNoteHandler) History(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	noteID, err := strconv.Atoi(c.Param("note_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := h.service.History(utils.GetUserID(c), int64(reminderID), int64(noteID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
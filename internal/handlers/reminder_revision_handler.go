@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderRevisionHandler struct {
+	service *services.ReminderRevisionService
+}
+
+func NewReminderRevisionHandler(service *services.ReminderRevisionService) *ReminderRevisionHandler {
+	return &ReminderRevisionHandler{
+		service: service,
+	}
+}
+
+// History godoc
+// @Summary      Get the history of a reminder
+// @Description  List every recorded change to a reminder, newest first
+// @Tags         reminder-history
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {array}   models.ReminderRevision
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/history [get]
+func (h *ReminderRevisionHandler) History(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	revisions, err := h.service.History(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// Revert godoc
+// @Summary      Revert a reminder
+// @Description  Put a reminder back into the state it had right after a revision
+// @Tags         reminder-history
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id        path      int  true  "Reminder ID"
+// @Param        revision  path      int  true  "Revision ID"
+// @Success      200       {object}  models.Reminder
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /reminders/{id}/revert/{revision} [post]
+func (h *ReminderRevisionHandler) Revert(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	revisionID, err := strconv.Atoi(c.Param("revision"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder, err := h.service.Revert(utils.GetUserID(c), int64(reminderID), int64(revisionID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminder)
+}
+
+// Undo godoc
+// @Summary      Undo the last change to a reminder
+// @Description  Reverse the latest change to a reminder, including deleting it, if it was made within the undo window
+// @Tags         reminder-history
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {object}  models.ReminderUndoResult
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/undo [post]
+func (h *ReminderRevisionHandler) Undo(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Undo(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderTemplateHandler struct {
+	service *services.ReminderTemplateService
+}
+
+func NewReminderTemplateHandler(service *services.ReminderTemplateService) *ReminderTemplateHandler {
+	return &ReminderTemplateHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List reminder templates
+// @Description  Get all reminder templates for the authenticated user
+// @Tags         reminder-templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   models.ReminderTemplate
+// @Failure      500  {object}  map[string]string
+// @Router       /reminder-templates/ [get]
+func (h *ReminderTemplateHandler) List(c *gin.Context) {
+	templates, err := h.service.List(utils.GetUserID(c))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// Get godoc
+// @Summary      Get a reminder template by ID
+// @Description  Get reminder template details by ID
+// @Tags         reminder-templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Template ID"
+// @Success      200  {object}  models.ReminderTemplate
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminder-templates/{id} [get]
+func (h *ReminderTemplateHandler) Get(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.service.Get(utils.GetUserID(c), int64(templateID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Create godoc
+// @Summary      Create a reminder template
+// @Description  Save a reminder layout that can be used to create reminders
+// @Tags         reminder-templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        template  body      models.ReminderTemplateCreateRequest  true  "Template data"
+// @Success      201       {object}  models.ReminderTemplate
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /reminder-templates/ [post]
+func (h *ReminderTemplateHandler) Create(c *gin.Context) {
+	var req models.ReminderTemplateCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.service.Create(utils.GetUserID(c), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// Update godoc
+// @Summary      Update a reminder template
+// @Description  Update the fields of a reminder template
+// @Tags         reminder-templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id        path      int                                   true  "Template ID"
+// @Param        template  body      models.ReminderTemplateUpdateRequest  true  "Template update data"
+// @Success      200       {object}  models.ReminderTemplate
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /reminder-templates/{id} [patch]
+func (h *ReminderTemplateHandler) Update(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderTemplateUpdateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.service.Update(utils.GetUserID(c), int64(templateID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Delete godoc
+// @Summary      Delete a reminder template
+// @Description  Delete a reminder template by ID
+// @Tags         reminder-templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Template ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminder-templates/{id} [delete]
+func (h *ReminderTemplateHandler) Delete(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Delete(utils.GetUserID(c), int64(templateID)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Instantiate godoc
+// @Summary      Create a reminder from a template
+// @Description  Create a reminder with its checklist and tags from a template, filling in {{date}}, {{month}}, {{year}} and custom variables
+// @Tags         reminder-templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id       path      int                                 true   "Template ID"
+// @Param        request  body      models.ReminderFromTemplateRequest  false  "Base date and variables"
+// @Success      201      {object}  models.Reminder
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /reminders/from-template/{id} [post]
+func (h *ReminderTemplateHandler) Instantiate(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.ReminderFromTemplateRequest
+
+	// The body is optional, everything in it has a default
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	reminder, err := h.service.Instantiate(utils.GetUserID(c), int64(templateID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reminder)
+}
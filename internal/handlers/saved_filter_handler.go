@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedFilterHandler struct {
+	service *services.SavedFilterService
+}
+
+func NewSavedFilterHandler(service *services.SavedFilterService) *SavedFilterHandler {
+	return &SavedFilterHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List saved filters
+// @Description  Get all saved filters for the authenticated user
+// @Tags         saved-filters
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   models.SavedFilter
+// @Failure      500  {object}  map[string]string
+// @Router       /saved-filters/ [get]
+func (h *SavedFilterHandler) List(c *gin.Context) {
+	savedFilters, err := h.service.List(utils.GetUserID(c))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, savedFilters)
+}
+
+// Get godoc
+// @Summary      Get a saved filter by ID
+// @Description  Get saved filter details by ID
+// @Tags         saved-filters
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Saved filter ID"
+// @Success      200  {object}  models.SavedFilter
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /saved-filters/{id} [get]
+func (h *SavedFilterHandler) Get(c *gin.Context) {
+	savedFilterID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	savedFilter, err := h.service.Get(utils.GetUserID(c), int64(savedFilterID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, savedFilter)
+}
+
+// Create godoc
+// @Summary      Create a saved filter
+// @Description  Save a reminder filter so it can be evaluated later
+// @Tags         saved-filters
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        filter  body      models.SavedFilterCreateRequest  true  "Saved filter data"
+// @Success      201     {object}  models.SavedFilter
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /saved-filters/ [post]
+func (h *SavedFilterHandler) Create(c *gin.Context) {
+	var req models.SavedFilterCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	savedFilter, err := h.service.Create(utils.GetUserID(c), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, savedFilter)
+}
+
+// Update godoc
+// @Summary      Update a saved filter
+// @Description  Rename a saved filter or replace its filter expression
+// @Tags         saved-filters
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id      path      int                              true  "Saved filter ID"
+// @Param        filter  body      models.SavedFilterUpdateRequest  true  "Saved filter update data"
+// @Success      200     {object}  models.SavedFilter
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /saved-filters/{id} [patch]
+func (h *SavedFilterHandler) Update(c *gin.Context) {
+	savedFilterID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.SavedFilterUpdateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	savedFilter, err := h.service.Update(utils.GetUserID(c), int64(savedFilterID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, savedFilter)
+}
+
+// Delete godoc
+// @Summary      Delete a saved filter
+// @Description  Delete a saved filter by ID
+// @Tags         saved-filters
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Saved filter ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /saved-filters/{id} [delete]
+func (h *SavedFilterHandler) Delete(c *gin.Context) {
+	savedFilterID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Delete(utils.GetUserID(c), int64(savedFilterID)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Reminders godoc
+// @Summary      Evaluate a saved filter
+// @Description  List the reminders matching a saved filter
+// @Tags         saved-filters
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Saved filter ID"
+// @Success      200  {array}   models.Reminder
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /saved-filters/{id}/reminders [get]
+func (h *SavedFilterHandler) Reminders(c *gin.Context) {
+	savedFilterID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminders, err := h.service.Reminders(utils.GetUserID(c), int64(savedFilterID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
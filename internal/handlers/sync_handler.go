@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SyncHandler struct {
+	service *services.SyncService
+}
+
+func NewSyncHandler(service *services.SyncService) *SyncHandler {
+	return &SyncHandler{
+		service: service,
+	}
+}
+
+// Pull godoc
+// @Summary      Pull changes
+// @Description  Get the reminders, categories and their children that were created, updated or deleted since a sync token. Leave since empty for a full sync.
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        since  query     string  false  "Token of the previous pull"
+// @Param        limit  query     int     false  "Maximum number of changes (default 500, max 1000)"
+// @Success      200    {object}  models.SyncPullResponse
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /sync [get]
+func (h *SyncHandler) Pull(c *gin.Context) {
+	var query models.SyncPullQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.Pull(utils.GetUserID(c), query)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Push godoc
+// @Summary      Push offline changes
+// @Description  Apply a batch of mutations made offline. Fields changed on the server since the token are resolved per field, the later write wins, and conflicts are reported per mutation.
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        batch  body      models.SyncPushRequest  true  "Mutations"
+// @Success      200    {object}  models.SyncPushResponse
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /sync [post]
+func (h *SyncHandler) Push(c *gin.Context) {
+	var req models.SyncPushRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.Push(utils.GetUserID(c), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
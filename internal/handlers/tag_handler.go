@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	service *services.TagService
+}
+
+func NewTagHandler(service *services.TagService) *TagHandler {
+	return &TagHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List tags
+// @Description  Get all tags of the authenticated user with their usage counts
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   models.Tag
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/ [get]
+func (h *TagHandler) List(c *gin.Context) {
+	tags, err := h.service.List(utils.GetUserID(c))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// Get godoc
+// @Summary      Get a tag by ID
+// @Description  Get tag details by ID
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Tag ID"
+// @Success      200  {object}  models.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/{id} [get]
+func (h *TagHandler) Get(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.service.Get(utils.GetUserID(c), int64(tagID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// Create godoc
+// @Summary      Create a tag
+// @Description  Create a new tag for the authenticated user
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        tag  body      models.TagCreateRequest  true  "Tag data"
+// @Success      201  {object}  models.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/ [post]
+func (h *TagHandler) Create(c *gin.Context) {
+	var req models.TagCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.service.Create(utils.GetUserID(c), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+// Update godoc
+// @Summary      Update a tag
+// @Description  Rename or recolor a tag
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int                      true  "Tag ID"
+// @Param        tag  body      models.TagUpdateRequest  true  "Tag update data"
+// @Success      200  {object}  models.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/{id} [patch]
+func (h *TagHandler) Update(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.TagUpdateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.service.Update(utils.GetUserID(c), int64(tagID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// Delete godoc
+// @Summary      Delete a tag
+// @Description  Delete a tag and remove it from every reminder
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Tag ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/{id} [delete]
+func (h *TagHandler) Delete(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Delete(utils.GetUserID(c), int64(tagID)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
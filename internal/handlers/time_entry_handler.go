@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reminder-server/internal/models"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeEntryHandler struct {
+	service *services.TimeEntryService
+}
+
+func NewTimeEntryHandler(service *services.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List time entries
+// @Description  Get the time tracked on a reminder, newest first. A running timer has no ended_at.
+// @Tags         time-tracking
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {array}   models.TimeEntry
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/time-entries/ [get]
+func (h *TimeEntryHandler) List(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := h.service.List(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// Create godoc
+// @Summary      Log time manually
+// @Description  Add a finished time entry to a reminder, given either its end or its length in minutes
+// @Tags         time-tracking
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id     path      int                            true  "Reminder ID"
+// @Param        entry  body      models.TimeEntryCreateRequest  true  "Time entry data"
+// @Success      201    {object}  models.TimeEntry
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /reminders/{id}/time-entries/ [post]
+func (h *TimeEntryHandler) Create(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.TimeEntryCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.Create(utils.GetUserID(c), int64(reminderID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// Delete godoc
+// @Summary      Delete a time entry
+// @Description  Delete a time entry of a reminder, which also discards a running timer
+// @Tags         time-tracking
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id        path  int  true  "Reminder ID"
+// @Param        entry_id  path  int  true  "Time entry ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/time-entries/{entry_id} [delete]
+func (h *TimeEntryHandler) Delete(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entryID, err := strconv.Atoi(c.Param("entry_id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Delete(utils.GetUserID(c), int64(reminderID), int64(entryID)); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// StartTimer godoc
+// @Summary      Start a timer
+// @Description  Start tracking time on a reminder. Only one timer can run at a time.
+// @Tags         time-tracking
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id     path      int                       true   "Reminder ID"
+// @Param        timer  body      models.TimerStartRequest  false  "Timer data"
+// @Success      201    {object}  models.TimeEntry
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /reminders/{id}/timer/start [post]
+func (h *TimeEntryHandler) StartTimer(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.TimerStartRequest
+
+	// The body is optional
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.StartTimer(utils.GetUserID(c), int64(reminderID), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// StopTimer godoc
+// @Summary      Stop a timer
+// @Description  Stop the timer running on a reminder and record the time spent
+// @Tags         time-tracking
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {object}  models.TimeEntry
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/timer/stop [post]
+func (h *TimeEntryHandler) StopTimer(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.StopTimer(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// ReminderSummary godoc
+// @Summary      Planned and tracked time of a reminder
+// @Description  Compare the estimate of a reminder with the time tracked on it
+// @Tags         time-tracking
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {object}  models.TimeSummary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/time [get]
+func (h *TimeEntryHandler) ReminderSummary(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary, err := h.service.ReminderSummary(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// CategorySummary godoc
+// @Summary      Planned and tracked time of a category
+// @Description  Add up the estimates and tracked time of the reminders in a category
+// @Tags         time-tracking
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  models.TimeSummary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /categories/{id}/time [get]
+func (h *TimeEntryHandler) CategorySummary(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary, err := h.service.CategorySummary(utils.GetUserID(c), int64(categoryID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
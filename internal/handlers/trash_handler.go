@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	service *services.TrashService
+}
+
+func NewTrashHandler(service *services.TrashService) *TrashHandler {
+	return &TrashHandler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List the trash
+// @Description  Get the deleted reminders and categories of the authenticated user
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  models.Trash
+// @Failure      500  {object}  map[string]string
+// @Router       /trash/ [get]
+func (h *TrashHandler) List(c *gin.Context) {
+	trash, err := h.service.List(utils.GetUserID(c))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// Empty godoc
+// @Summary      Empty the trash
+// @Description  Permanently delete every reminder and category in the trash
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Success      204  {object}  nil
+// @Failure      500  {object}  map[string]string
+// @Router       /trash/ [delete]
+func (h *TrashHandler) Empty(c *gin.Context) {
+	if err := h.service.Empty(utils.GetUserID(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// RestoreReminder godoc
+// @Summary      Restore a reminder
+// @Description  Take a deleted reminder out of the trash, along with its category if needed
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Reminder ID"
+// @Success      200  {object}  models.Reminder
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reminders/{id}/restore [post]
+func (h *TrashHandler) RestoreReminder(c *gin.Context) {
+	reminderID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder, err := h.service.RestoreReminder(utils.GetUserID(c), int64(reminderID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminder)
+}
+
+// RestoreCategory godoc
+// @Summary      Restore a category
+// @Description  Take a deleted category out of the trash
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  models.Category
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /categories/{id}/restore [post]
+func (h *TrashHandler) RestoreCategory(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.service.RestoreCategory(utils.GetUserID(c), int64(categoryID))
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
@@ -139,3 +139,34 @@ func (h *UserHandler) Login(c *gin.Context) {
 
 	c.JSON(http.StatusOK, user)
 }
+
+// UpdateMe godoc
+// @Summary      Update the current user
+// @Description  Update the settings of the authenticated user, such as their time zone
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        user  body      models.UserUpdateRequest  true  "User settings"
+// @Success      200   {object}  models.User
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /users/me [patch]
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	var req models.UserUpdateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userService.Update(utils.GetUserID(c), req)
+
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
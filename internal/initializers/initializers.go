@@ -1,14 +1,20 @@
 package initializers
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"log"
 	"os"
 	"reminder-server/internal/handlers"
-	"reminder-server/internal/models"
+	"reminder-server/internal/jobs"
+	"reminder-server/internal/seed"
 	"reminder-server/internal/services"
+	"reminder-server/internal/storage"
+	"time"
 
 	"github.com/joho/godotenv"
-	_ "github.com/tursodatabase/libsql-client-go/libsql"
+	"github.com/tursodatabase/libsql-client-go/libsql"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -18,11 +24,26 @@ type Config struct {
 }
 
 type Initializers struct {
-	CategoryHandler *handlers.CategoryHandler
-	ReminderHandler *handlers.ReminderHandler
-	UserHandler     *handlers.UserHandler
-	HealthHandler   *handlers.HealthHandler
-	Config          *Config
+	CategoryHandler     *handlers.CategoryHandler
+	ReminderHandler     *handlers.ReminderHandler
+	UserHandler         *handlers.UserHandler
+	HealthHandler       *handlers.HealthHandler
+	SavedFilterHandler  *handlers.SavedFilterHandler
+	ReminderItemHandler *handlers.ReminderItemHandler
+	TagHandler          *handlers.TagHandler
+	ReminderNoteHandler *handlers.ReminderNoteHandler
+	AttachmentHandler   *handlers.AttachmentHandler
+	TrashHandler        *handlers.TrashHandler
+	ReminderBulkHandler *handlers.ReminderBulkHandler
+	TemplateHandler     *handlers.ReminderTemplateHandler
+	QuickAddHandler     *handlers.QuickAddHandler
+	DependencyHandler   *handlers.ReminderDependencyHandler
+	RevisionHandler     *handlers.ReminderRevisionHandler
+	SyncHandler         *handlers.SyncHandler
+	TimeEntryHandler    *handlers.TimeEntryHandler
+	DuplicateHandler    *handlers.DuplicateHandler
+	IdempotencyService  *services.IdempotencyService
+	Config              *Config
 }
 
 var (
@@ -54,9 +75,11 @@ func GetDBString() string {
 }
 
 func ConnectDB() {
+	conn := sql.OpenDB(foreignKeysConnector{dsn: GetDBString()})
+
 	db, err := gorm.Open(sqlite.New(sqlite.Config{
 		DriverName: "libsql",
-		DSN:        GetDBString(),
+		Conn:       conn,
 	}), &gorm.Config{})
 
 	if err != nil {
@@ -66,19 +89,125 @@ func ConnectDB() {
 	DB = db
 }
 
+// foreignKeysConnector opens libsql connections with foreign key enforcement
+// turned on. SQLite keeps the setting per connection, so it is set on every
+// connection the pool opens.
+type foreignKeysConnector struct {
+	dsn string
+}
+
+func (fc foreignKeysConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := fc.Driver().Open(fc.dsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := execPragma(ctx, conn, "PRAGMA foreign_keys = ON"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (fc foreignKeysConnector) Driver() driver.Driver {
+	return libsql.Driver{}
+}
+
+func execPragma(ctx context.Context, conn driver.Conn, pragma string) error {
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, pragma, nil)
+		return err
+	}
+
+	stmt, err := conn.Prepare(pragma)
+
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(nil)
+
+	return err
+}
+
 func GetDB() *gorm.DB {
 	return DB
 }
 
+// ConnectStorage sets up the blob store for attachments. STORAGE_DRIVER picks
+// between the local filesystem (default) and an S3-compatible bucket.
+func ConnectStorage() {
+	var store storage.BlobStore
+	var err error
+
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "local":
+		path := os.Getenv("STORAGE_LOCAL_PATH")
+
+		if path == "" {
+			path = "data/attachments"
+		}
+
+		store, err = storage.NewLocalStore(path)
+	case "s3":
+		store, err = storage.NewS3Store(storage.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			UsePathStyle:    os.Getenv("S3_USE_PATH_STYLE") == "true",
+		})
+	default:
+		log.Fatalf("Unknown STORAGE_DRIVER: %q", driver)
+	}
+
+	if err != nil {
+		log.Fatalf("Error setting up attachment storage: %v", err)
+	}
+
+	storage.SetDefault(store)
+}
+
+// LoadSeeds reads the categories new users start out with from the JSON file
+// at SEED_CATEGORIES_FILE, keeping the built-in set when it is unset.
+func LoadSeeds() {
+	categories, err := seed.LoadCategories(os.Getenv("SEED_CATEGORIES_FILE"))
+
+	if err != nil {
+		log.Fatalf("Error loading seed categories: %v", err)
+	}
+
+	seed.SetDefaultCategories(categories)
+}
+
 func NewInitializers() *Initializers {
 	LoadEnv()
 	ConnectDB()
+	ConnectStorage()
+	LoadSeeds()
 
 	categoryService := services.NewCategoryService(DB)
 	reminderService := services.NewReminderService(DB)
 	userService := services.NewUserService(DB)
-
-	seedCategories(categoryService)
+	savedFilterService := services.NewSavedFilterService(DB)
+	reminderItemService := services.NewReminderItemService(DB)
+	tagService := services.NewTagService(DB)
+	reminderNoteService := services.NewReminderNoteService(DB)
+	attachmentService := services.NewAttachmentService(DB)
+	trashService := services.NewTrashService(DB)
+	reminderBulkService := services.NewReminderBulkService(DB)
+	templateService := services.NewReminderTemplateService(DB)
+	quickAddService := services.NewQuickAddService(DB)
+	dependencyService := services.NewReminderDependencyService(DB)
+	revisionService := services.NewReminderRevisionService(DB)
+	syncService := services.NewSyncService(DB)
+	timeEntryService := services.NewTimeEntryService(DB)
+	duplicateService := services.NewDuplicateService(DB)
 
 	port := os.Getenv("PORT")
 
@@ -91,52 +220,36 @@ func NewInitializers() *Initializers {
 	}
 
 	return &Initializers{
-		CategoryHandler: handlers.NewCategoryHandler(categoryService),
-		ReminderHandler: handlers.NewReminderHandler(reminderService),
-		UserHandler:     handlers.NewUserHandler(userService),
-		HealthHandler:   handlers.NewHealthHandler(DB),
-		Config:          config,
+		CategoryHandler:     handlers.NewCategoryHandler(categoryService),
+		ReminderHandler:     handlers.NewReminderHandler(reminderService),
+		UserHandler:         handlers.NewUserHandler(userService),
+		HealthHandler:       handlers.NewHealthHandler(DB),
+		SavedFilterHandler:  handlers.NewSavedFilterHandler(savedFilterService),
+		ReminderItemHandler: handlers.NewReminderItemHandler(reminderItemService),
+		TagHandler:          handlers.NewTagHandler(tagService),
+		ReminderNoteHandler: handlers.NewReminderNoteHandler(reminderNoteService),
+		AttachmentHandler:   handlers.NewAttachmentHandler(attachmentService),
+		TrashHandler:        handlers.NewTrashHandler(trashService),
+		ReminderBulkHandler: handlers.NewReminderBulkHandler(reminderBulkService),
+		TemplateHandler:     handlers.NewReminderTemplateHandler(templateService),
+		QuickAddHandler:     handlers.NewQuickAddHandler(quickAddService),
+		DependencyHandler:   handlers.NewReminderDependencyHandler(dependencyService),
+		RevisionHandler:     handlers.NewReminderRevisionHandler(revisionService),
+		SyncHandler:         handlers.NewSyncHandler(syncService),
+		TimeEntryHandler:    handlers.NewTimeEntryHandler(timeEntryService),
+		DuplicateHandler:    handlers.NewDuplicateHandler(duplicateService),
+		IdempotencyService:  services.NewIdempotencyService(DB),
+		Config:              config,
 	}
 }
 
-func seedCategories(categoryService *services.CategoryService) {
-	// Check if the categories are already seeded
-	dbCategories, err := categoryService.List(1)
-
-	if err == nil && len(dbCategories) > 0 {
-		return
-	}
-
-	var categories = []models.Category{
-		{
-			Name:   "Work",
-			Icon:   "💼",
-			Color:  "#0077B6",
-			UserID: 1,
-		},
-		{
-			Name:   "Personal",
-			Icon:   "👤",
-			Color:  "#FFA500",
-			UserID: 1,
-		},
-		{
-			Name:   "Shopping",
-			Icon:   "🛒",
-			Color:  "#C0392B",
-			UserID: 1,
-		},
-		{
-			Name:   "Education",
-			Icon:   "📚",
-			Color:  "#8E44AD",
-			UserID: 1,
-		},
-	}
-
-	err = categoryService.CreateBulk(categories)
+// StartJobs schedules the background maintenance jobs.
+func StartJobs() {
+	trashService := services.NewTrashService(DB)
+	reminderService := services.NewReminderService(DB)
+	idempotencyService := services.NewIdempotencyService(DB)
 
-	if err != nil {
-		log.Fatal("Error seeding categories")
-	}
+	jobs.Every("purge-trash", time.Hour, trashService.PurgeExpired)
+	jobs.Every("archive-completed", time.Hour, reminderService.ArchiveCompleted)
+	jobs.Every("purge-idempotency-keys", time.Hour, idempotencyService.PurgeExpired)
 }
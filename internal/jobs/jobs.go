@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"log"
+	"time"
+)
+
+// Every runs job in the background right away and then once per interval
+// for the lifetime of the process. Errors and panics are logged so one bad
+// run doesn't stop the schedule.
+func Every(name string, interval time.Duration, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			run(name, job)
+			<-ticker.C
+		}
+	}()
+}
+
+func run(name string, job func() error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %s panicked: %v", name, r)
+		}
+	}()
+
+	start := time.Now()
+
+	if err := job(); err != nil {
+		log.Printf("Job %s failed: %v", name, err)
+		return
+	}
+
+	log.Printf("Job %s finished in %v", name, time.Since(start))
+}
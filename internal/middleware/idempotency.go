@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"reminder-server/internal/services"
+	"reminder-server/internal/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// Request bodies are read into memory to fingerprint them, so they are
+// capped. Uploads aren't read here at all.
+const defaultIdempotencyMaxBodyBytes = 1 << 20
+
+// idempotentMethods are the methods an Idempotency-Key header is honoured on
+var idempotentMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// replayedHeaders are stored with a response and sent again on replay next
+// to its Content-Type
+var replayedHeaders = []string{"ETag", "Location"}
+
+var idempotencyErrorStatuses = map[string]int{
+	utils.ErrorIdempotencyKeyReused:     http.StatusUnprocessableEntity,
+	utils.ErrorIdempotencyKeyInProgress: http.StatusConflict,
+}
+
+// Idempotency replays the stored response when a mutating request is retried
+// with the same Idempotency-Key header, so a retry after a lost response
+// doesn't apply the change twice. Reusing a key for a different request is
+// rejected. Server errors aren't stored, leaving the key free for a retry.
+func Idempotency(service *services.IdempotencyService) gin.HandlerFunc {
+	maxBodyBytes := utils.GetEnvInt64("IDEMPOTENCY_MAX_BODY_BYTES", defaultIdempotencyMaxBodyBytes)
+
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+
+		if key == "" || !idempotentMethods[c.Request.Method] {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": utils.ErrorInvalidIdempotencyKey})
+			return
+		}
+
+		body, err := fingerprintBody(c, maxBodyBytes)
+
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": utils.ErrorRequestTooLarge})
+				return
+			}
+
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		record, claimed, err := service.Begin(utils.GetUserID(c), key, requestFingerprint(c.Request, body))
+
+		if err != nil {
+			status, ok := idempotencyErrorStatuses[err.Error()]
+
+			if !ok {
+				status = http.StatusInternalServerError
+			}
+
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !claimed {
+			for name, value := range record.Headers {
+				c.Header(name, value)
+			}
+
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(record.StatusCode, record.ContentType, record.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// A handler that panics never gets to store its response, so the key
+		// is freed for a retry instead of staying in progress
+		finished := false
+
+		defer func() {
+			if finished {
+				return
+			}
+
+			if err := service.Release(record); err != nil {
+				log.Printf("Failed to release idempotency key %q: %v", key, err)
+			}
+		}()
+
+		c.Next()
+
+		finished = true
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			err = service.Release(record)
+		} else {
+			err = service.Complete(record, recorder.Status(), recorder.Header().Get("Content-Type"), storedHeaders(recorder.Header()), recorder.body.Bytes())
+		}
+
+		if err != nil {
+			log.Printf("Failed to store idempotency key %q: %v", key, err)
+		}
+	}
+}
+
+// fingerprintBody returns what of the body goes into the request's
+// fingerprint. Other bodies are read up to the limit and put back for the
+// handler, while multipart uploads are left to stream to storage and only
+// their length is used, so a retried upload must keep its size.
+func fingerprintBody(c *gin.Context, maxBodyBytes int64) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return []byte(strconv.FormatInt(c.Request.ContentLength, 10)), nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// storedHeaders picks the replayed headers the response set.
+func storedHeaders(header http.Header) map[string]string {
+	stored := make(map[string]string)
+
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			stored[name] = value
+		}
+	}
+
+	return stored
+}
+
+// requestFingerprint identifies a request by its method, target and body.
+func requestFingerprint(request *http.Request, body []byte) string {
+	hash := sha256.New()
+
+	io.WriteString(hash, request.Method+" "+request.URL.RequestURI()+"\n")
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body as it's written.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(data string) (int, error) {
+	r.body.WriteString(data)
+
+	return r.ResponseWriter.WriteString(data)
+}
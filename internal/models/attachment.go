@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Attachment struct {
+	ID          int64      `json:"id"`
+	ReminderID  int64      `json:"reminder_id"`
+	UserID      int64      `json:"user_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	StorageKey  string     `json:"-"`
+	CreatedAt   *time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
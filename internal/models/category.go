@@ -1,23 +1,101 @@
 package models
 
+import "gorm.io/gorm"
+
 type Category struct {
-	ID        int64  `json:"id"`
-	Name      string `json:"name"`
-	Color     string `json:"color"`
-	Icon      string `json:"icon"`
-	UserID    int64  `json:"user_id"`
-	CreatedAt string `json:"created_at"`
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Color     string         `json:"color"`
+	Icon      string         `json:"icon"`
+	ParentID  *int64         `json:"parent_id"`
+	UserID    int64          `json:"user_id"`
+	Version   int64          `json:"version"`
+	CreatedAt string         `json:"created_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+
+	// Defaults for reminders created in the category without these fields.
+	// DefaultDueTime is a time of day like 09:00 in the user's time zone.
+	DefaultPriority string  `json:"default_priority"`
+	DefaultDueTime  string  `json:"default_due_time"`
+	DefaultTagIDs   []int64 `json:"default_tag_ids" gorm:"serializer:json"`
+
+	// WIPLimit warns when the category holds more pending reminders, 0 turns
+	// it off
+	WIPLimit int `json:"wip_limit" gorm:"column:wip_limit"`
+
+	// Children is only filled in when categories are listed as a tree
+	Children []Category `json:"children,omitempty" gorm:"-"`
 }
 
+// CategoryCreateRequest creates a category of the authenticated user. Color
+// is a hex color like #0077B6 and Icon a single emoji or a known icon key.
+// Without ParentID the category is created at the top level.
 type CategoryCreateRequest struct {
-	Name   string `json:"name" binding:"required"`
-	Color  string `json:"color"`
-	Icon   string `json:"icon"`
-	UserID int32  `json:"user_id"`
+	Name            string  `json:"name" binding:"required,max=100"`
+	Color           string  `json:"color"`
+	Icon            string  `json:"icon"`
+	ParentID        *int64  `json:"parent_id"`
+	DefaultPriority string  `json:"default_priority" binding:"omitempty,oneof=low medium high"`
+	DefaultDueTime  string  `json:"default_due_time"`
+	DefaultTagIDs   []int64 `json:"default_tag_ids"`
+	WIPLimit        int     `json:"wip_limit" binding:"min=0"`
 }
 
+// CategoryUpdateRequest changes the given fields. An empty default priority
+// or due time and an empty list of default tags clear them.
 type CategoryUpdateRequest struct {
-	Name  *string `json:"name"`
-	Color *string `json:"color"`
-	Icon  *string `json:"icon"`
+	Name            *string  `json:"name" binding:"omitempty,max=100"`
+	Color           *string  `json:"color"`
+	Icon            *string  `json:"icon"`
+	DefaultPriority *string  `json:"default_priority" binding:"omitempty,oneof=low medium high"`
+	DefaultDueTime  *string  `json:"default_due_time"`
+	DefaultTagIDs   *[]int64 `json:"default_tag_ids"`
+	WIPLimit        *int     `json:"wip_limit" binding:"omitempty,min=0"`
+}
+
+// CategoryMoveRequest places a category under another one, or at the top
+// level when ParentID is null. Its subcategories move along with it.
+type CategoryMoveRequest struct {
+	ParentID *int64 `json:"parent_id"`
+}
+
+// CategoryDuplicateRequest copies a category with its reminders. The copy is
+// named after the original unless Name is given.
+type CategoryDuplicateRequest struct {
+	Name      string `json:"name" binding:"max=100"`
+	ShiftDays int    `json:"shift_days" binding:"min=-3650,max=3650"`
+}
+
+// CategoryMergeRequest names the categories merged into another one.
+type CategoryMergeRequest struct {
+	SourceIDs []int64 `json:"source_ids" binding:"required,min=1"`
+}
+
+// CategoryMergeResponse holds the merged category and counts what was moved
+// into it from the sources, which are in the trash afterwards.
+type CategoryMergeResponse struct {
+	Category      Category `json:"category"`
+	MergedIDs     []int64  `json:"merged_ids"`
+	Reminders     int      `json:"reminders"`
+	Templates     int      `json:"templates"`
+	Subcategories int      `json:"subcategories"`
+	SavedFilters  int      `json:"saved_filters"`
+}
+
+// TimeOfDayLayout is how a category's default due time is written
+const TimeOfDayLayout = "15:04"
+
+const (
+	CategoryDeleteReject   = "reject"
+	CategoryDeleteReassign = "reassign"
+	CategoryDeleteCascade  = "cascade"
+)
+
+// CategoryDeleteQuery says what happens to the reminders, templates and
+// subcategories of a deleted category. They are moved to Target on reassign
+// and go to the trash with it on cascade, while reject refuses to delete a
+// category still in use.
+type CategoryDeleteQuery struct {
+	Strategy string `form:"strategy" binding:"omitempty,oneof=reject reassign cascade"`
+	Target   *int64 `form:"target"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// IdempotencyKey remembers the response to a mutating request so a retry
+// with the same Idempotency-Key header gets it replayed instead of running
+// the request again. StatusCode stays 0 while the first request is running.
+type IdempotencyKey struct {
+	ID          int64             `json:"id"`
+	UserID      int64             `json:"user_id"`
+	Key         string            `json:"key"`
+	Fingerprint string            `json:"fingerprint"`
+	StatusCode  int               `json:"status_code"`
+	ContentType string            `json:"content_type"`
+	Headers     map[string]string `json:"-" gorm:"serializer:json"`
+	Body        []byte            `json:"-"`
+	CreatedAt   *time.Time        `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type QuickAddRequest struct {
+	Text string `json:"text" binding:"required,max=500"`
+}
+
+// QuickAddParse shows how the quick-add text was understood
+type QuickAddParse struct {
+	Title     string     `json:"title"`
+	DueDate   *time.Time `json:"due_date"`
+	HasTime   bool       `json:"has_time"`
+	RRule     string     `json:"rrule,omitempty"`
+	Priority  string     `json:"priority"`
+	Category  *Category  `json:"category"`
+	Tags      []string   `json:"tags"`
+	NewTags   []string   `json:"new_tags,omitempty"`
+	Timezone  string     `json:"timezone"`
+	Matched   []string   `json:"matched"`
+	Defaulted []string   `json:"defaulted,omitempty"`
+}
+
+// QuickAddResponse holds the created reminder, which is left out when the
+// request was a dry run.
+type QuickAddResponse struct {
+	Reminder *Reminder     `json:"reminder,omitempty"`
+	Parsed   QuickAddParse `json:"parsed"`
+}
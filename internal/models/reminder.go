@@ -2,22 +2,40 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Reminder struct {
-	ID               int64      `json:"id"`
-	Title            string     `json:"title"`
-	Description      string     `json:"description"`
-	CategoryID       int64      `json:"category_id"`
-	DueDate          *time.Time `json:"due_date" gorm:"type:date"`
-	Priority         string     `json:"priority"`
-	Status           string     `json:"status"`
-	IsRecurring      bool       `json:"is_recurring"`
-	RecurringPattern string     `json:"recurring_pattern,omitempty"`
-	UserID           int64      `json:"user_id"`
-	IsOverdue        bool       `json:"is_overdue" gorm:"-"`
-	CreatedAt        *time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt        *time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID               int64          `json:"id"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	CategoryID       int64          `json:"category_id"`
+	DueDate          *time.Time     `json:"due_date" gorm:"type:date"`
+	StartDate        *time.Time     `json:"start_date" gorm:"type:date"`
+	EstimatedMinutes *int           `json:"estimated_minutes"`
+	Priority         string         `json:"priority"`
+	Status           string         `json:"status"`
+	IsRecurring      bool           `json:"is_recurring"`
+	RecurringPattern string         `json:"recurring_pattern,omitempty"`
+	AutoComplete     bool           `json:"auto_complete"`
+	Pinned           bool           `json:"pinned"`
+	Flagged          bool           `json:"flagged"`
+	CompletedAt      *time.Time     `json:"completed_at"`
+	ArchivedAt       *time.Time     `json:"archived_at"`
+	UserID           int64          `json:"user_id"`
+	Position         string         `json:"position"`
+	Version          int64          `json:"version"`
+	IsOverdue        bool           `json:"is_overdue" gorm:"-"`
+	Blocked          bool           `json:"blocked" gorm:"-"`
+	Warnings         []string       `json:"warnings,omitempty" gorm:"-"`
+	CreatedAt        *time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        *time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at"`
+
+	Tags     []Tag             `json:"tags" gorm:"many2many:reminder_tags"`
+	Notes    []ReminderNote    `json:"notes,omitempty" gorm:"foreignKey:ReminderID"`
+	Progress *ReminderProgress `json:"progress,omitempty" gorm:"-"`
 }
 
 type ReminderResponse struct {
@@ -34,14 +52,23 @@ type ReminderResponse struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 }
 
+// ReminderCreateRequest creates a reminder. Priority, DueDate and TagIDs may
+// be left out to use the category's defaults, but DueDate is only optional
+// when the category has a default due time.
 type ReminderCreateRequest struct {
-	Title            string    `json:"title" binding:"required"`
-	Description      string    `json:"description"`
-	CategoryID       int64     `json:"category_id" binding:"required"`
-	DueDate          time.Time `json:"due_date" binding:"required"`
-	Priority         string    `json:"priority" binding:"required,oneof=low medium high"`
-	IsRecurring      bool      `json:"is_recurring"`
-	RecurringPattern string    `json:"recurring_pattern,omitempty"`
+	Title            string     `json:"title" binding:"required"`
+	Description      string     `json:"description"`
+	CategoryID       int64      `json:"category_id" binding:"required"`
+	DueDate          *time.Time `json:"due_date"`
+	StartDate        *time.Time `json:"start_date,omitempty"`
+	EstimatedMinutes *int       `json:"estimated_minutes,omitempty" binding:"omitempty,min=1"`
+	Priority         string     `json:"priority" binding:"omitempty,oneof=low medium high"`
+	IsRecurring      bool       `json:"is_recurring"`
+	RecurringPattern string     `json:"recurring_pattern,omitempty"`
+	AutoComplete     bool       `json:"auto_complete"`
+	Pinned           bool       `json:"pinned"`
+	Flagged          bool       `json:"flagged"`
+	TagIDs           []int64    `json:"tag_ids,omitempty"`
 }
 
 // ReminderUpdateRequest for updating existing reminders
@@ -50,10 +77,45 @@ type ReminderUpdateRequest struct {
 	Description      *string    `json:"description,omitempty"`
 	CategoryID       *int64     `json:"category_id,omitempty"`
 	DueDate          *time.Time `json:"due_date,omitempty"`
+	StartDate        *time.Time `json:"start_date,omitempty"`
+	EstimatedMinutes *int       `json:"estimated_minutes,omitempty" binding:"omitempty,min=1"`
 	Priority         *string    `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"`
 	Status           *string    `json:"status,omitempty" binding:"omitempty,oneof=pending completed"`
 	IsRecurring      *bool      `json:"is_recurring,omitempty"`
 	RecurringPattern *string    `json:"recurring_pattern,omitempty"`
+	AutoComplete     *bool      `json:"auto_complete,omitempty"`
+	Pinned           *bool      `json:"pinned,omitempty"`
+	Flagged          *bool      `json:"flagged,omitempty"`
+
+	// TagIDs replaces every tag of the reminder, while AddTagIDs and
+	// RemoveTagIDs change individual assignments
+	TagIDs       *[]int64 `json:"tag_ids,omitempty"`
+	AddTagIDs    []int64  `json:"add_tag_ids,omitempty"`
+	RemoveTagIDs []int64  `json:"remove_tag_ids,omitempty"`
+}
+
+// ReminderFilter holds the query parameters accepted when listing reminders.
+// Saved filters store the same structure, so every field maps to a fixed,
+// parameterised condition in the repository rather than raw SQL.
+type ReminderFilter struct {
+	Status      string     `json:"status,omitempty" form:"status" binding:"omitempty,oneof=pending completed"`
+	Priority    string     `json:"priority,omitempty" form:"priority" binding:"omitempty,oneof=low medium high"`
+	CategoryID  *int64     `json:"category_id,omitempty" form:"category_id"`
+	IsRecurring *bool      `json:"is_recurring,omitempty" form:"is_recurring"`
+	Flagged     *bool      `json:"flagged,omitempty" form:"flagged"`
+	Search      string     `json:"search,omitempty" form:"search" binding:"max=100"`
+	TagIDs      []int64    `json:"tag_ids,omitempty" form:"tag_ids"`
+	TagMatch    string     `json:"tag_match,omitempty" form:"tag_match" binding:"omitempty,oneof=any all"`
+	DueFrom     *time.Time `json:"due_from,omitempty" form:"due_from"`
+	DueTo       *time.Time `json:"due_to,omitempty" form:"due_to"`
+	DueWithin   string     `json:"due_within,omitempty" form:"due_within" binding:"omitempty,oneof=overdue today tomorrow this_week next_7_days this_month"`
+	Sort        string     `json:"sort,omitempty" form:"sort" binding:"omitempty,oneof=due_date -due_date priority created_at -created_at title manual"`
+
+	// IncludeArchived also returns archived reminders, which are hidden by default
+	IncludeArchived bool `json:"include_archived,omitempty" form:"include_archived"`
+
+	// IncludeDescendants widens CategoryID to the category's whole subtree
+	IncludeDescendants bool `json:"include_descendants,omitempty" form:"include_descendants"`
 }
 
 // Constants for reminder status and priority
@@ -66,3 +128,28 @@ const (
 	PriorityMedium = "medium"
 	PriorityHigh   = "high"
 )
+
+// Relative due date windows accepted by ReminderFilter.DueWithin
+const (
+	DueWithinOverdue   = "overdue"
+	DueWithinToday     = "today"
+	DueWithinTomorrow  = "tomorrow"
+	DueWithinThisWeek  = "this_week"
+	DueWithinNext7Days = "next_7_days"
+	DueWithinThisMonth = "this_month"
+)
+
+// ReminderMoveRequest places a reminder right before or after another one,
+// taking over its category, or at the end of a category.
+type ReminderMoveRequest struct {
+	BeforeID   *int64 `json:"before_id,omitempty"`
+	AfterID    *int64 `json:"after_id,omitempty"`
+	CategoryID *int64 `json:"category_id,omitempty"`
+}
+
+// ReminderDuplicateRequest copies a reminder, by default into the same
+// category. ShiftDays moves the due and start dates of the copy.
+type ReminderDuplicateRequest struct {
+	CategoryID *int64 `json:"category_id,omitempty"`
+	ShiftDays  int    `json:"shift_days" binding:"min=-3650,max=3650"`
+}
@@ -0,0 +1,40 @@
+package models
+
+// Actions accepted by ReminderBulkRequest.Action
+const (
+	BulkActionComplete    = "complete"
+	BulkActionReopen      = "reopen"
+	BulkActionDelete      = "delete"
+	BulkActionMove        = "move"
+	BulkActionSetPriority = "set_priority"
+	BulkActionShiftDue    = "shift_due"
+	BulkActionAddTags     = "add_tags"
+	BulkActionRemoveTags  = "remove_tags"
+)
+
+// ReminderBulkRequest applies one action to several reminders, picked either
+// by ID or by a filter. Only the fields needed by the action are read.
+type ReminderBulkRequest struct {
+	IDs    []int64         `json:"ids,omitempty"`
+	Filter *ReminderFilter `json:"filter,omitempty"`
+	Action string          `json:"action" binding:"required,oneof=complete reopen delete move set_priority shift_due add_tags remove_tags"`
+
+	CategoryID *int64  `json:"category_id,omitempty"`
+	Priority   string  `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"`
+	ShiftBy    string  `json:"shift_by,omitempty"` // Go duration, e.g. "24h" or "-30m"
+	TagIDs     []int64 `json:"tag_ids,omitempty"`
+}
+
+// ReminderBulkResult says how the action went for one reminder. Warnings are
+// the same ones a single-reminder request would have returned.
+type ReminderBulkResult struct {
+	ID       int64    `json:"id"`
+	Success  bool     `json:"success"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type ReminderBulkResponse struct {
+	Action  string               `json:"action"`
+	Results []ReminderBulkResult `json:"results"`
+}
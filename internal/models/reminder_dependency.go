@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// ReminderDependency records that a reminder can't be completed until the
+// reminder it is blocked by is done.
+type ReminderDependency struct {
+	ReminderID  int64      `json:"reminder_id" gorm:"primaryKey;autoIncrement:false"`
+	BlockedByID int64      `json:"blocked_by_id" gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt   *time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// ReminderDependencies lists both sides of a reminder's dependencies
+type ReminderDependencies struct {
+	BlockedBy []Reminder `json:"blocked_by"`
+	Blocking  []Reminder `json:"blocking"`
+}
+
+type ReminderDependencyCreateRequest struct {
+	BlockedByID int64 `json:"blocked_by_id" binding:"required"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+type ReminderItem struct {
+	ID         int64      `json:"id"`
+	ReminderID int64      `json:"reminder_id"`
+	Text       string     `json:"text"`
+	Done       bool       `json:"done"`
+	Position   int        `json:"position"`
+	CreatedAt  *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  *time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ReminderProgress summarises the checklist items of a reminder
+type ReminderProgress struct {
+	ReminderID int64 `json:"-"`
+	Total      int   `json:"total"`
+	Done       int   `json:"done"`
+}
+
+type ReminderItemCreateRequest struct {
+	Text     string `json:"text" binding:"required,max=500"`
+	Done     bool   `json:"done"`
+	Position *int   `json:"position,omitempty" binding:"omitempty,min=0"`
+}
+
+type ReminderItemUpdateRequest struct {
+	Text *string `json:"text,omitempty" binding:"omitempty,min=1,max=500"`
+	Done *bool   `json:"done,omitempty"`
+}
+
+// ReminderItemReorderRequest lists every item of a reminder in its new order
+type ReminderItemReorderRequest struct {
+	ItemIDs []int64 `json:"item_ids" binding:"required"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ReminderNote is a Markdown comment left on a reminder. Edits keep the
+// previous body as a ReminderNoteRevision.
+type ReminderNote struct {
+	ID         int64      `json:"id"`
+	ReminderID int64      `json:"reminder_id"`
+	AuthorID   int64      `json:"author_id"`
+	Body       string     `json:"body"`
+	CreatedAt  *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  *time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Revisions []ReminderNoteRevision `json:"revisions,omitempty" gorm:"foreignKey:NoteID"`
+}
+
+type ReminderNoteRevision struct {
+	ID        int64      `json:"id"`
+	NoteID    int64      `json:"note_id"`
+	Body      string     `json:"body"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type ReminderNoteCreateRequest struct {
+	Body string `json:"body" binding:"required,max=10000"`
+}
+
+type ReminderNoteUpdateRequest struct {
+	Body string `json:"body" binding:"required,max=10000"`
+}
+
+// Related data that can be requested with ?include= when fetching a reminder
+const (
+	IncludeNotes = "notes"
+)
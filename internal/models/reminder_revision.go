@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// ReminderRevision records one change to a reminder. Before and After hold
+// the reminder's own fields and tags around the change; items and notes are
+// not part of the history.
+type ReminderRevision struct {
+	ID         int64                          `json:"id"`
+	ReminderID int64                          `json:"reminder_id"`
+	Action     string                         `json:"action"`
+	ActorID    *int64                         `json:"actor_id"`
+	Changes    map[string]ReminderFieldChange `json:"changes" gorm:"serializer:json"`
+	Before     *ReminderSnapshot              `json:"before,omitempty" gorm:"serializer:json"`
+	After      *ReminderSnapshot              `json:"after,omitempty" gorm:"serializer:json"`
+	CreatedAt  *time.Time                     `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type ReminderFieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ReminderSnapshot is the part of a reminder that revisions track
+type ReminderSnapshot struct {
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	CategoryID       int64      `json:"category_id"`
+	DueDate          *time.Time `json:"due_date"`
+	StartDate        *time.Time `json:"start_date,omitempty"`
+	EstimatedMinutes *int       `json:"estimated_minutes,omitempty"`
+	Priority         string     `json:"priority"`
+	Status           string     `json:"status"`
+	IsRecurring      bool       `json:"is_recurring"`
+	RecurringPattern string     `json:"recurring_pattern"`
+	AutoComplete     bool       `json:"auto_complete"`
+	Pinned           bool       `json:"pinned"`
+	Flagged          bool       `json:"flagged"`
+	CompletedAt      *time.Time `json:"completed_at"`
+	ArchivedAt       *time.Time `json:"archived_at"`
+	Position         string     `json:"position,omitempty"`
+	// TagIDs is missing from revisions recorded before tags were tracked
+	TagIDs []int64 `json:"tag_ids"`
+}
+
+// ReminderUndoResult describes the revision that was undone and the reminder
+// afterwards, which is left out if undoing deleted it.
+type ReminderUndoResult struct {
+	Undone   ReminderRevision `json:"undone"`
+	Reminder *Reminder        `json:"reminder,omitempty"`
+}
+
+// Actions recorded in ReminderRevision.Action
+const (
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionStatus  = "status"
+	RevisionActionArchive = "archive"
+	RevisionActionMove    = "move"
+	RevisionActionDelete  = "delete"
+	RevisionActionRestore = "restore"
+	RevisionActionRevert  = "revert"
+	RevisionActionUndo    = "undo"
+)
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReminderTemplate describes a reminder that gets created over and over. The
+// title, description and items may contain {{variables}} that are filled in
+// when a reminder is created from the template.
+type ReminderTemplate struct {
+	ID               int64          `json:"id"`
+	Name             string         `json:"name"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	CategoryID       int64          `json:"category_id"`
+	Priority         string         `json:"priority"`
+	IsRecurring      bool           `json:"is_recurring"`
+	RecurringPattern string         `json:"recurring_pattern,omitempty"`
+	AutoComplete     bool           `json:"auto_complete"`
+	DueOffsetMinutes int            `json:"due_offset_minutes"`
+	Items            []string       `json:"items" gorm:"serializer:json"`
+	TagIDs           []int64        `json:"tag_ids" gorm:"serializer:json"`
+	UserID           int64          `json:"user_id"`
+	CreatedAt        *time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        *time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt `json:"-"`
+}
+
+type ReminderTemplateCreateRequest struct {
+	Name             string   `json:"name" binding:"required,max=100"`
+	Title            string   `json:"title" binding:"required"`
+	Description      string   `json:"description"`
+	CategoryID       int64    `json:"category_id" binding:"required"`
+	Priority         string   `json:"priority" binding:"required,oneof=low medium high"`
+	IsRecurring      bool     `json:"is_recurring"`
+	RecurringPattern string   `json:"recurring_pattern,omitempty"`
+	AutoComplete     bool     `json:"auto_complete"`
+	DueOffsetMinutes int      `json:"due_offset_minutes" binding:"min=0"`
+	Items            []string `json:"items,omitempty" binding:"dive,required,max=500"`
+	TagIDs           []int64  `json:"tag_ids,omitempty"`
+}
+
+type ReminderTemplateUpdateRequest struct {
+	Name             *string   `json:"name,omitempty" binding:"omitempty,max=100"`
+	Title            *string   `json:"title,omitempty" binding:"omitempty,min=1"`
+	Description      *string   `json:"description,omitempty"`
+	CategoryID       *int64    `json:"category_id,omitempty"`
+	Priority         *string   `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"`
+	IsRecurring      *bool     `json:"is_recurring,omitempty"`
+	RecurringPattern *string   `json:"recurring_pattern,omitempty"`
+	AutoComplete     *bool     `json:"auto_complete,omitempty"`
+	DueOffsetMinutes *int      `json:"due_offset_minutes,omitempty" binding:"omitempty,min=0"`
+	Items            *[]string `json:"items,omitempty" binding:"omitempty,dive,required,max=500"`
+	TagIDs           *[]int64  `json:"tag_ids,omitempty"`
+}
+
+// ReminderFromTemplateRequest sets the date the due offset is counted from,
+// which defaults to now, and any extra variables used by the template.
+type ReminderFromTemplateRequest struct {
+	Date      *time.Time        `json:"date,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type SavedFilter struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Filter    ReminderFilter `json:"filter" gorm:"serializer:json"`
+	UserID    int64          `json:"user_id"`
+	CreatedAt *time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt *time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type SavedFilterCreateRequest struct {
+	Name   string         `json:"name" binding:"required,max=100"`
+	Filter ReminderFilter `json:"filter"`
+}
+
+type SavedFilterUpdateRequest struct {
+	Name   *string         `json:"name,omitempty" binding:"omitempty,max=100"`
+	Filter *ReminderFilter `json:"filter,omitempty"`
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Entity types recorded in the change log
+const (
+	SyncEntityReminder   = "reminder"
+	SyncEntityCategory   = "category"
+	SyncEntityItem       = "item"
+	SyncEntityNote       = "note"
+	SyncEntityAttachment = "attachment"
+	SyncEntityTag        = "tag"
+)
+
+// Operations recorded in the change log. Moving a row to the trash counts as
+// a delete and restoring it as a create.
+const (
+	ChangeOperationCreate = "create"
+	ChangeOperationUpdate = "update"
+	ChangeOperationDelete = "delete"
+)
+
+// Outcomes of a pushed mutation
+const (
+	SyncStatusApplied  = "applied"
+	SyncStatusMerged   = "merged"
+	SyncStatusRejected = "rejected"
+	SyncStatusFailed   = "failed"
+)
+
+// ChangeLogEntry is written by database triggers on every change to a synced
+// entity. Fields lists the columns an update changed.
+type ChangeLogEntry struct {
+	ID         int64     `json:"id"`
+	UserID     *int64    `json:"user_id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   int64     `json:"entity_id"`
+	Operation  string    `json:"operation"`
+	Fields     string    `json:"fields"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (ChangeLogEntry) TableName() string {
+	return "change_log"
+}
+
+type SyncPullQuery struct {
+	Since string `form:"since"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+// SyncEntityChanges lists what happened to one type of entity since the
+// token. Deleted only holds ids.
+type SyncEntityChanges[T any] struct {
+	Created []T     `json:"created"`
+	Updated []T     `json:"updated"`
+	Deleted []int64 `json:"deleted"`
+}
+
+// SyncPullResponse holds the changes after the requested token. Token is
+// passed as since on the next pull, which should follow right away while
+// HasMore is set.
+type SyncPullResponse struct {
+	Token       string                          `json:"token"`
+	HasMore     bool                            `json:"has_more"`
+	Reminders   SyncEntityChanges[Reminder]     `json:"reminders"`
+	Categories  SyncEntityChanges[Category]     `json:"categories"`
+	Items       SyncEntityChanges[ReminderItem] `json:"items"`
+	Notes       SyncEntityChanges[ReminderNote] `json:"notes"`
+	Attachments SyncEntityChanges[Attachment]   `json:"attachments"`
+	Tags        SyncEntityChanges[Tag]          `json:"tags"`
+}
+
+// SyncPushRequest is a batch of mutations a client made offline. Since is
+// the token of the client's last pull; server changes after it are treated
+// as concurrent with the mutations.
+type SyncPushRequest struct {
+	Since     string         `json:"since"`
+	Mutations []SyncMutation `json:"mutations" binding:"required,max=500,dive"`
+}
+
+// SyncMutation is one offline change. Fields use the same names as the
+// regular create and update requests. A create may set ClientID so later
+// mutations in the batch can pass it as a string in place of the new id in
+// category_id or reminder_id. ModifiedAt is when the change was made on the
+// client and decides per field whether it wins over a concurrent change.
+type SyncMutation struct {
+	ClientID   string                     `json:"client_id"`
+	Entity     string                     `json:"entity" binding:"required,oneof=reminder category item"`
+	Operation  string                     `json:"op" binding:"required,oneof=create update delete"`
+	ID         int64                      `json:"id"`
+	Fields     map[string]json.RawMessage `json:"fields"`
+	ModifiedAt time.Time                  `json:"modified_at" binding:"required"`
+}
+
+// Sides of a conflict
+const (
+	SyncWinnerClient = "client"
+	SyncWinnerServer = "server"
+)
+
+// SyncFieldConflict reports a field that was also changed on the server
+// since the client's token, and which side's value was kept. Field is empty
+// when a delete conflicted with any server change.
+type SyncFieldConflict struct {
+	Field           string          `json:"field,omitempty"`
+	ClientValue     json.RawMessage `json:"client_value,omitempty"`
+	ServerChangedAt time.Time       `json:"server_changed_at"`
+	Winner          string          `json:"winner"`
+}
+
+type SyncMutationResult struct {
+	ClientID  string              `json:"client_id,omitempty"`
+	Entity    string              `json:"entity"`
+	Operation string              `json:"op"`
+	ID        int64               `json:"id,omitempty"`
+	Status    string              `json:"status"`
+	Conflicts []SyncFieldConflict `json:"conflicts,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+type SyncPushResponse struct {
+	Results []SyncMutationResult `json:"results"`
+}
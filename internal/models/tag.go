@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type Tag struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Color      string     `json:"color"`
+	UserID     int64      `json:"user_id"`
+	UsageCount int64      `json:"usage_count" gorm:"->"`
+	CreatedAt  *time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type TagCreateRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color"`
+}
+
+type TagUpdateRequest struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,min=1,max=50"`
+	Color *string `json:"color,omitempty"`
+}
+
+// Tag match modes accepted by ReminderFilter.TagMatch
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
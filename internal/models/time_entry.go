@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// TimeEntry is time spent on a reminder. An entry without EndedAt is a
+// running timer.
+type TimeEntry struct {
+	ID              int64      `json:"id"`
+	UserID          int64      `json:"user_id"`
+	ReminderID      int64      `json:"reminder_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Note            string     `json:"note"`
+	CreatedAt       *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       *time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TimeEntryCreateRequest logs time manually, either up to EndedAt or for a
+// number of minutes from StartedAt.
+type TimeEntryCreateRequest struct {
+	StartedAt time.Time  `json:"started_at" binding:"required"`
+	EndedAt   *time.Time `json:"ended_at,omitempty" binding:"required_without=Minutes,excluded_with=Minutes"`
+	Minutes   *int       `json:"minutes,omitempty" binding:"omitempty,min=1"`
+	Note      string     `json:"note" binding:"max=500"`
+}
+
+type TimerStartRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// TimeSummary compares the planned effort with the time tracked so far.
+// TrackedMinutes includes a running timer up to now.
+type TimeSummary struct {
+	EstimatedMinutes int        `json:"estimated_minutes"`
+	TrackedMinutes   int64      `json:"tracked_minutes"`
+	EntryCount       int64      `json:"entry_count"`
+	RunningSince     *time.Time `json:"running_since,omitempty"`
+}
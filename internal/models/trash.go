@@ -0,0 +1,7 @@
+package models
+
+// Trash lists the soft-deleted items of a user
+type Trash struct {
+	Reminders  []Reminder `json:"reminders"`
+	Categories []Category `json:"categories"`
+}
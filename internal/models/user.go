@@ -6,6 +6,7 @@ type User struct {
 	ID        int64  `json:"id"`
 	Email     string `json:"email"`
 	Password  string `json:"-"`
+	Timezone  string `json:"timezone"`
 	CreatedAt string `json:"created_at"`
 }
 
@@ -20,6 +21,11 @@ type UserResponse struct {
 type UserCreateRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+	Timezone string `json:"timezone" binding:"omitempty,timezone"`
+}
+
+type UserUpdateRequest struct {
+	Timezone *string `json:"timezone,omitempty" binding:"omitempty,timezone"`
 }
 
 type UserLoginRequest struct {
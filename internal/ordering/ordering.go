@@ -0,0 +1,177 @@
+// Package ordering generates sort keys for user-defined ordering. Keys are
+// strings compared byte by byte, and a key between any two others can always
+// be made, so moving one element never renumbers its neighbours.
+package ordering
+
+import (
+	"errors"
+	"strings"
+)
+
+// digits are in ascending byte order so keys sort with plain string
+// comparison, including in SQL.
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// initialKey is the first key of an empty list. It leaves room on both sides
+// and is wide enough for appending to count up without growing.
+const initialKey = "V00001"
+
+// MaxKeyLength is how long keys may get before the list should be spread
+// out again. Inserting into the same gap over and over makes keys longer.
+const MaxKeyLength = 24
+
+var ErrInvalidRange = errors.New("ordering: keys are not in ascending order")
+
+// Between returns a key that sorts after a and before b. An empty a means
+// the start of the list and an empty b its end.
+func Between(a string, b string) (string, error) {
+	if !valid(a) || !valid(b) || (b != "" && a >= b) {
+		return "", ErrInvalidRange
+	}
+
+	switch {
+	case a == "" && b == "":
+		return initialKey, nil
+	case b == "":
+		// Appending counts up at the same width, so keys stay short
+		if key, ok := step(a, 1); ok {
+			return key, nil
+		}
+	case a == "":
+		if key, ok := step(b, -1); ok {
+			return key, nil
+		}
+	}
+
+	return midpoint(a, b), nil
+}
+
+// Spread returns n ascending keys spaced evenly, for renumbering a list
+// whose keys have grown too long.
+func Spread(n int) []string {
+	const width = 5
+
+	space := 1
+
+	for i := 0; i < width; i++ {
+		space *= len(digits)
+	}
+
+	gap := space / (n + 1)
+	keys := make([]string, n)
+
+	for i := range keys {
+		value := (i + 1) * gap
+		key := make([]byte, width)
+
+		for j := width - 1; j >= 0; j-- {
+			key[j] = digits[value%len(digits)]
+			value /= len(digits)
+		}
+
+		// The gap is at least 2 for any realistic n, so this can't pass
+		// the next key
+		if key[width-1] == digits[0] {
+			key[width-1] = digits[1]
+		}
+
+		keys[i] = initialKey[:1] + string(key)
+	}
+
+	return keys
+}
+
+// step adds delta to the key as a fixed-width number, skipping results with
+// a trailing zero. It fails when the key would need another digit.
+func step(key string, delta int) (string, bool) {
+	next := []byte(key)
+
+	for {
+		i := len(next) - 1
+
+		for ; i >= 0; i-- {
+			value := strings.IndexByte(digits, next[i]) + delta
+
+			if value >= 0 && value < len(digits) {
+				next[i] = digits[value]
+				break
+			}
+
+			// Carry or borrow into the next digit
+			next[i] = digits[(value+len(digits))%len(digits)]
+		}
+
+		if i < 0 {
+			return "", false
+		}
+
+		if valid(string(next)) {
+			return string(next), true
+		}
+	}
+}
+
+// midpoint works on keys without trailing zeros, which is all it produces.
+func midpoint(a string, b string) string {
+	if b != "" {
+		// Skip the common prefix, padding a with zeros
+		n := 0
+
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+
+		if n > 0 {
+			return b[:n] + midpoint(suffix(a, n), b[n:])
+		}
+	}
+
+	low := 0
+
+	if a != "" {
+		low = strings.IndexByte(digits, a[0])
+	}
+
+	high := len(digits)
+
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+
+	if high-low > 1 {
+		return string(digits[(low+high+1)/2])
+	}
+
+	// The first digits are consecutive, so the key has to get longer
+	if len(b) > 1 {
+		return b[:1]
+	}
+
+	return string(digits[low]) + midpoint(suffix(a, 1), "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+
+	return digits[0]
+}
+
+func suffix(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+
+	return key[n:]
+}
+
+func valid(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+
+	return !strings.HasSuffix(key, digits[:1])
+}
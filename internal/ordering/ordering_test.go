@@ -0,0 +1,107 @@
+package ordering
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+		err  error
+	}{
+		{name: "empty list", want: initialKey},
+		{name: "append counts up", a: "V00001", want: "V00002"},
+		{name: "prepend counts down with a borrow", b: "V00001", want: "Uzzzzz"},
+		{name: "append at the width limit", a: "zzzzzz", want: "zzzzzzV"},
+		{name: "prepend at the width limit", b: "000001", want: "000000V"},
+		{name: "prepend before a single digit", b: "1", want: "0V"},
+		{name: "gap in the first digit", a: "A", b: "C", want: "B"},
+		{name: "adjacent digits", a: "A", b: "B", want: "AV"},
+		{name: "adjacent keys of the same width", a: "V00001", b: "V00002", want: "V00001V"},
+		{name: "b extends a", a: "A", b: "A1", want: "A0V"},
+		{name: "a ends in the last digit", a: "Az", b: "B", want: "AzV"},
+		{name: "equal keys", a: "A", b: "A", err: ErrInvalidRange},
+		{name: "descending keys", a: "B", b: "A", err: ErrInvalidRange},
+		{name: "trailing zero", a: "A0", err: ErrInvalidRange},
+		{name: "invalid digit", a: "A!", err: ErrInvalidRange},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Between(test.a, test.b)
+
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Between(%q, %q) error = %v, want %v", test.a, test.b, err, test.err)
+			}
+
+			if got != test.want {
+				t.Errorf("Between(%q, %q) = %q, want %q", test.a, test.b, got, test.want)
+			}
+
+			if err == nil && !inRange(got, test.a, test.b) {
+				t.Errorf("Between(%q, %q) = %q, which is out of range", test.a, test.b, got)
+			}
+		})
+	}
+}
+
+func TestSpreadThenInsert(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{name: "one key", n: 1},
+		{name: "a few keys", n: 3},
+		{name: "a long list", n: 1000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys := Spread(test.n)
+
+			if len(keys) != test.n {
+				t.Fatalf("Spread(%d) returned %d keys", test.n, len(keys))
+			}
+
+			for i, key := range keys {
+				if !valid(key) || len(key) != len(initialKey) {
+					t.Fatalf("Spread(%d)[%d] = %q, want a valid key of width %d", test.n, i, key, len(initialKey))
+				}
+
+				if i > 0 && keys[i-1] >= key {
+					t.Fatalf("Spread(%d) isn't ascending at %d: %q >= %q", test.n, i, keys[i-1], key)
+				}
+			}
+
+			// Insert before the first key, after the last and into every gap
+			bounds := append(append([]string{""}, keys...), "")
+
+			for i := 0; i+1 < len(bounds); i++ {
+				a, b := bounds[i], bounds[i+1]
+				key, err := Between(a, b)
+
+				if err != nil {
+					t.Fatalf("Between(%q, %q): %v", a, b, err)
+				}
+
+				if !inRange(key, a, b) {
+					t.Errorf("Between(%q, %q) = %q, which is out of range", a, b, key)
+				}
+
+				if len(key) > MaxKeyLength {
+					t.Errorf("Between(%q, %q) = %q, which is longer than %d", a, b, key, MaxKeyLength)
+				}
+			}
+		})
+	}
+}
+
+// inRange reports whether key is valid and sorts strictly between a and b,
+// where empty bounds are the ends of the list.
+func inRange(key string, a string, b string) bool {
+	return valid(key) && key != "" && key > a && (b == "" || key < b)
+}
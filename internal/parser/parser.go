@@ -0,0 +1,725 @@
+// Package parser turns a free-text quick-add line such as
+// "Pay rent every 1st of the month at 9am #Personal !high" into the parts of
+// a reminder: title, due date, recurrence rule, priority and hashtags.
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hour used when a due date is given without a time of day
+const defaultHour = 9
+
+type Result struct {
+	Title    string
+	DueDate  *time.Time
+	HasTime  bool
+	RRule    string
+	Priority string
+	Hashtags []string
+
+	// Matched lists the phrases that were recognised, in input order
+	Matched []string
+}
+
+type clock struct {
+	hour   int
+	minute int
+}
+
+type rule struct {
+	freq       string
+	interval   int
+	byDay      []time.Weekday
+	byMonthDay int
+}
+
+type parser struct {
+	now   time.Time
+	words []string // lower case, trailing punctuation removed
+	orig  []string
+
+	date  *time.Time // midnight of the due day
+	clock *clock
+	exact *time.Time
+	rule  *rule
+
+	result Result
+	title  []string
+}
+
+var (
+	clockPattern   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|a\.m\.|p\.m\.)?$`)
+	ordinalPattern = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)$`)
+	isoDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	yearPattern    = regexp.MustCompile(`^\d{4}$`)
+)
+
+// ErrNoTitle is returned for text made up only of dates, priorities and tags.
+var ErrNoTitle = errors.New("parser: no title left after parsing")
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+	"sunday": time.Sunday,
+}
+
+// Short weekday names are common words ("sun", "sat"), so they only count
+// after a word like "on" or "every".
+var shortWeekdays = map[string]time.Weekday{
+	"mon": time.Monday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wed": time.Wednesday, "thu": time.Thursday, "thur": time.Thursday,
+	"thurs": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+var rruleDays = map[time.Weekday]string{
+	time.Monday: "MO", time.Tuesday: "TU", time.Wednesday: "WE", time.Thursday: "TH",
+	time.Friday: "FR", time.Saturday: "SA", time.Sunday: "SU",
+}
+
+var priorities = map[string]string{
+	"!low": "low", "!medium": "medium", "!med": "medium", "!high": "high",
+	"!!": "medium", "!!!": "high",
+}
+
+// Parse reads a quick-add line. Relative dates are resolved against now,
+// whose location is taken to be the user's time zone. Text that leaves no
+// title is rejected with ErrNoTitle.
+func Parse(input string, now time.Time) (Result, error) {
+	p := &parser{now: now}
+
+	for _, word := range strings.Fields(input) {
+		p.orig = append(p.orig, word)
+		p.words = append(p.words, strings.TrimRight(strings.ToLower(word), ",.;"))
+	}
+
+	for i := 0; i < len(p.words); {
+		if n := p.match(i); n > 0 {
+			p.result.Matched = append(p.result.Matched, strings.TrimRight(strings.Join(p.orig[i:i+n], " "), ",.;"))
+			i += n
+			continue
+		}
+
+		p.title = append(p.title, p.orig[i])
+		i++
+	}
+
+	p.result.Title = strings.Trim(strings.Join(p.title, " "), " ,;:-")
+
+	if p.result.Title == "" {
+		return Result{}, ErrNoTitle
+	}
+
+	p.resolve()
+
+	return p.result, nil
+}
+
+func (p *parser) match(i int) int {
+	word := p.words[i]
+
+	if strings.HasPrefix(word, "#") && len(word) > 1 {
+		p.result.Hashtags = append(p.result.Hashtags, strings.TrimRight(p.orig[i][1:], ",.;"))
+		return 1
+	}
+
+	if priority, ok := priorities[word]; ok {
+		p.result.Priority = priority
+		return 1
+	}
+
+	switch word {
+	case "every", "each":
+		return p.matchEvery(i)
+	case "daily", "weekly", "monthly", "yearly", "annually":
+		p.rule = &rule{freq: map[string]string{
+			"daily": "DAILY", "weekly": "WEEKLY", "monthly": "MONTHLY", "yearly": "YEARLY", "annually": "YEARLY",
+		}[word], interval: 1}
+		return 1
+	case "at", "@":
+		if c, n := p.parseClock(i+1, true); n > 0 {
+			p.clock = &c
+			return n + 1
+		}
+
+		return 0
+	case "in":
+		return p.matchIn(i)
+	case "on", "by", "this", "next":
+		if n := p.matchDate(i+1, true, word); n > 0 {
+			return n + 1
+		}
+
+		return 0
+	}
+
+	if c, n := p.parseClock(i, false); n > 0 {
+		p.clock = &c
+		return n
+	}
+
+	return p.matchDate(i, false, "")
+}
+
+// matchDate recognises a day: today, tomorrow, a weekday, "next week", an
+// absolute date or an ordinal day of the month.
+func (p *parser) matchDate(i int, prefixed bool, prefix string) int {
+	if i >= len(p.words) {
+		return 0
+	}
+
+	today := p.today()
+	word := p.words[i]
+
+	switch word {
+	case "today":
+		p.setDate(today)
+		return 1
+	case "tonight":
+		p.setDate(today)
+
+		if p.clock == nil {
+			p.clock = &clock{hour: 20}
+		}
+
+		return 1
+	case "tomorrow", "tmr", "tmrw":
+		p.setDate(today.AddDate(0, 0, 1))
+		return 1
+	case "week":
+		if prefix != "next" {
+			return 0
+		}
+
+		p.setDate(today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7))
+		return 1
+	case "month":
+		if prefix != "next" {
+			return 0
+		}
+
+		p.setDate(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()))
+		return 1
+	case "year":
+		if prefix != "next" {
+			return 0
+		}
+
+		p.setDate(time.Date(today.Year()+1, time.January, 1, 0, 0, 0, 0, today.Location()))
+		return 1
+	}
+
+	if weekday, ok := p.weekday(word, prefixed); ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+
+		if days == 0 && prefix != "this" {
+			days = 7
+		}
+
+		p.setDate(today.AddDate(0, 0, days))
+		return 1
+	}
+
+	if isoDatePattern.MatchString(word) {
+		date, err := time.ParseInLocation("2006-01-02", word, today.Location())
+
+		if err != nil {
+			return 0
+		}
+
+		p.setDate(date)
+		return 1
+	}
+
+	if n := p.matchMonthDay(i); n > 0 {
+		return n
+	}
+
+	if word == "the" || prefixed {
+		return p.matchOrdinal(i)
+	}
+
+	return 0
+}
+
+// matchMonthDay recognises "nov 5", "november 5th 2026", "5 nov" and
+// "5th of november".
+func (p *parser) matchMonthDay(i int) int {
+	var month time.Month
+	var day, n int
+
+	if m, ok := months[p.words[i]]; ok && i+1 < len(p.words) {
+		if d, ok := dayNumber(p.words[i+1]); ok {
+			month, day, n = m, d, 2
+		}
+	} else if d, ok := dayNumber(p.words[i]); ok && i+1 < len(p.words) {
+		next := i + 1
+
+		if p.words[next] == "of" && next+1 < len(p.words) {
+			next++
+		}
+
+		if m, ok := months[p.words[next]]; ok {
+			month, day, n = m, d, next-i+1
+		}
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	today := p.today()
+	year := today.Year()
+	hasYear := false
+
+	if i+n < len(p.words) && yearPattern.MatchString(p.words[i+n]) {
+		year, _ = strconv.Atoi(p.words[i+n])
+		hasYear = true
+		n++
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, today.Location())
+
+	if date.Month() != month {
+		return 0
+	}
+
+	if !hasYear && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+
+	p.setDate(date)
+
+	return n
+}
+
+// matchOrdinal recognises "the 15th" as the next 15th of a month, and
+// "the 1st of every month" as a monthly recurrence.
+func (p *parser) matchOrdinal(i int) int {
+	n := 0
+
+	if p.words[i] == "the" {
+		n++
+	}
+
+	if i+n >= len(p.words) {
+		return 0
+	}
+
+	day, ok := ordinal(p.words[i+n])
+
+	if !ok {
+		return 0
+	}
+
+	n++
+
+	if rest := p.words[i+n:]; len(rest) >= 3 && rest[0] == "of" && (rest[1] == "every" || rest[1] == "each") && rest[2] == "month" {
+		p.rule = &rule{freq: "MONTHLY", interval: 1, byMonthDay: day}
+		return n + 3
+	}
+
+	if rest := p.words[i+n:]; len(rest) >= 3 && rest[0] == "of" && rest[1] == "the" && rest[2] == "month" {
+		n += 3
+	}
+
+	today := p.today()
+	date := time.Date(today.Year(), today.Month(), day, 0, 0, 0, 0, today.Location())
+
+	for date.Day() != day || date.Before(today) {
+		today = time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location())
+		date = time.Date(today.Year(), today.Month(), day, 0, 0, 0, 0, today.Location())
+	}
+
+	p.setDate(date)
+
+	return n
+}
+
+// matchEvery recognises recurrences: "every day", "every 2 weeks", "every
+// other month", "every weekday", "every monday and friday" and "every 1st
+// (of the month)".
+func (p *parser) matchEvery(i int) int {
+	rest := p.words[i+1:]
+
+	if len(rest) == 0 {
+		return 0
+	}
+
+	interval, n := 1, 0
+
+	if rest[0] == "other" {
+		interval, n = 2, 1
+	} else if number, err := strconv.Atoi(rest[0]); err == nil && number > 0 {
+		interval, n = number, 1
+	}
+
+	if n < len(rest) {
+		if freq, ok := frequencyUnit(rest[n]); ok {
+			p.rule = &rule{freq: freq, interval: interval}
+			return n + 2
+		}
+	}
+
+	if n > 0 {
+		return 0
+	}
+
+	switch rest[0] {
+	case "weekday":
+		p.rule = &rule{freq: "WEEKLY", interval: 1, byDay: []time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+		}}
+		return 2
+	case "weekend":
+		p.rule = &rule{freq: "WEEKLY", interval: 1, byDay: []time.Weekday{time.Saturday, time.Sunday}}
+		return 2
+	}
+
+	if day, ok := ordinal(rest[0]); ok {
+		p.rule = &rule{freq: "MONTHLY", interval: 1, byMonthDay: day}
+		n = 1
+
+		if len(rest) >= 4 && rest[1] == "of" && (rest[2] == "the" || rest[2] == "every" || rest[2] == "each") && rest[3] == "month" {
+			n = 4
+		}
+
+		return n + 1
+	}
+
+	var days []time.Weekday
+
+	for n < len(rest) {
+		weekday, ok := p.weekday(rest[n], true)
+
+		if !ok {
+			break
+		}
+
+		days = append(days, weekday)
+		n++
+
+		if n+1 < len(rest) && (rest[n] == "and" || rest[n] == "&") {
+			if _, ok := p.weekday(rest[n+1], true); ok {
+				n++
+			}
+		}
+	}
+
+	if len(days) == 0 {
+		return 0
+	}
+
+	p.rule = &rule{freq: "WEEKLY", interval: 1, byDay: days}
+
+	return n + 1
+}
+
+// matchIn recognises "in 2 hours", "in an hour" and "in 3 days".
+func (p *parser) matchIn(i int) int {
+	if i+2 >= len(p.words) {
+		return 0
+	}
+
+	amount, err := strconv.Atoi(p.words[i+1])
+
+	if p.words[i+1] == "a" || p.words[i+1] == "an" {
+		amount, err = 1, nil
+	}
+
+	if err != nil || amount <= 0 {
+		return 0
+	}
+
+	switch strings.TrimSuffix(p.words[i+2], "s") {
+	case "minute", "min":
+		p.setExact(p.now.Add(time.Duration(amount) * time.Minute))
+	case "hour", "hr":
+		p.setExact(p.now.Add(time.Duration(amount) * time.Hour))
+	case "day":
+		p.setDate(p.today().AddDate(0, 0, amount))
+	case "week":
+		p.setDate(p.today().AddDate(0, 0, 7*amount))
+	case "month":
+		p.setDate(p.today().AddDate(0, amount, 0))
+	default:
+		return 0
+	}
+
+	return 3
+}
+
+// parseClock reads a time of day. Bare numbers like "9" only count as a
+// time after "at"; otherwise an am/pm suffix or minutes are required. Without
+// am/pm, hours 1 to 7 are taken as the afternoon, since "at 3" rarely means
+// 3am. Written with a leading zero, as in "03:00", they are taken as given.
+func (p *parser) parseClock(i int, afterAt bool) (clock, int) {
+	if i >= len(p.words) {
+		return clock{}, 0
+	}
+
+	switch p.words[i] {
+	case "noon", "midday":
+		return clock{hour: 12}, 1
+	case "midnight":
+		return clock{}, 1
+	}
+
+	match := clockPattern.FindStringSubmatch(p.words[i])
+
+	if match == nil {
+		return clock{}, 0
+	}
+
+	n := 1
+	meridiem := match[3]
+
+	if meridiem == "" && i+1 < len(p.words) {
+		switch p.words[i+1] {
+		case "am", "pm", "a.m.", "p.m.":
+			meridiem = p.words[i+1]
+			n++
+		}
+	}
+
+	if meridiem == "" && match[2] == "" && !afterAt {
+		return clock{}, 0
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+
+	if minute > 59 {
+		return clock{}, 0
+	}
+
+	if meridiem == "" {
+		if hour > 23 {
+			return clock{}, 0
+		}
+
+		if hour >= 1 && hour <= 7 && len(match[1]) == 1 {
+			hour += 12
+		}
+
+		return clock{hour: hour, minute: minute}, n
+	}
+
+	if hour < 1 || hour > 12 {
+		return clock{}, 0
+	}
+
+	hour %= 12
+
+	if strings.HasPrefix(meridiem, "p") {
+		hour += 12
+	}
+
+	return clock{hour: hour, minute: minute}, n
+}
+
+func (p *parser) weekday(word string, allowShort bool) (time.Weekday, bool) {
+	if weekday, ok := weekdays[strings.TrimSuffix(word, "s")]; ok {
+		return weekday, true
+	}
+
+	if weekday, ok := shortWeekdays[word]; ok && allowShort {
+		return weekday, true
+	}
+
+	return 0, false
+}
+
+func (p *parser) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+func (p *parser) setDate(date time.Time) {
+	p.date = &date
+	p.exact = nil
+}
+
+func (p *parser) setExact(at time.Time) {
+	p.exact = &at
+	p.date = nil
+}
+
+// resolve combines the recognised parts into a due date and RRULE.
+func (p *parser) resolve() {
+	p.result.HasTime = p.clock != nil || p.exact != nil
+
+	c := clock{hour: defaultHour}
+
+	if p.clock != nil {
+		c = *p.clock
+	}
+
+	switch {
+	case p.rule != nil:
+		start := p.today()
+
+		// "every week on friday" names the day separately from the rule
+		if p.date != nil {
+			start = *p.date
+
+			if p.rule.freq == "WEEKLY" && len(p.rule.byDay) == 0 {
+				p.rule.byDay = []time.Weekday{start.Weekday()}
+			}
+
+			if p.rule.freq == "MONTHLY" && p.rule.byMonthDay == 0 {
+				p.rule.byMonthDay = start.Day()
+			}
+		}
+
+		due := p.rule.firstOccurrence(start, c, p.now)
+		p.result.DueDate = &due
+		p.result.RRule = p.rule.String(p.clock)
+	case p.exact != nil:
+		due := *p.exact
+
+		if p.clock != nil {
+			due = time.Date(due.Year(), due.Month(), due.Day(), c.hour, c.minute, 0, 0, due.Location())
+		}
+
+		p.result.DueDate = &due
+	case p.date != nil:
+		due := time.Date(p.date.Year(), p.date.Month(), p.date.Day(), c.hour, c.minute, 0, 0, p.date.Location())
+		p.result.DueDate = &due
+	case p.clock != nil:
+		today := p.today()
+		due := time.Date(today.Year(), today.Month(), today.Day(), c.hour, c.minute, 0, 0, today.Location())
+
+		if due.Before(p.now) {
+			due = due.AddDate(0, 0, 1)
+		}
+
+		p.result.DueDate = &due
+	}
+}
+
+// firstOccurrence finds the first day on or after start that fits the rule
+// and whose time hasn't passed yet.
+func (r *rule) firstOccurrence(start time.Time, c clock, now time.Time) time.Time {
+	for days := 0; days < 800; days++ {
+		day := start.AddDate(0, 0, days)
+		at := time.Date(day.Year(), day.Month(), day.Day(), c.hour, c.minute, 0, 0, day.Location())
+
+		if at.Before(now) || !r.matches(day) {
+			continue
+		}
+
+		return at
+	}
+
+	return time.Date(start.Year(), start.Month(), start.Day(), c.hour, c.minute, 0, 0, start.Location())
+}
+
+func (r *rule) matches(day time.Time) bool {
+	if r.byMonthDay > 0 && day.Day() != r.byMonthDay {
+		return false
+	}
+
+	if len(r.byDay) == 0 {
+		return true
+	}
+
+	for _, weekday := range r.byDay {
+		if day.Weekday() == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String formats the rule as an RFC 5545 RRULE value.
+func (r *rule) String(c *clock) string {
+	parts := []string{"FREQ=" + r.freq}
+
+	if r.interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval))
+	}
+
+	if len(r.byDay) > 0 {
+		days := make([]string, len(r.byDay))
+
+		for i, weekday := range r.byDay {
+			days[i] = rruleDays[weekday]
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.byMonthDay > 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.byMonthDay))
+	}
+
+	if c != nil {
+		parts = append(parts, "BYHOUR="+strconv.Itoa(c.hour), "BYMINUTE="+strconv.Itoa(c.minute))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func frequencyUnit(word string) (string, bool) {
+	switch strings.TrimSuffix(word, "s") {
+	case "day":
+		return "DAILY", true
+	case "week":
+		return "WEEKLY", true
+	case "month":
+		return "MONTHLY", true
+	case "year":
+		return "YEARLY", true
+	}
+
+	return "", false
+}
+
+// ordinal reads "1st" to "31st".
+func ordinal(word string) (int, bool) {
+	match := ordinalPattern.FindStringSubmatch(word)
+
+	if match == nil {
+		return 0, false
+	}
+
+	day, _ := strconv.Atoi(match[1])
+
+	return day, day >= 1 && day <= 31
+}
+
+// dayNumber reads a day of the month written as "5" or "5th".
+func dayNumber(word string) (int, bool) {
+	if day, ok := ordinal(word); ok {
+		return day, true
+	}
+
+	day, err := strconv.Atoi(word)
+
+	return day, err == nil && day >= 1 && day <= 31
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParse(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A Friday morning, two days before clocks go forward on March 8
+	now := time.Date(2026, time.March, 6, 10, 0, 0, 0, location)
+	at := func(month time.Month, day int, hour int, minute int) string {
+		return time.Date(2026, month, day, hour, minute, 0, 0, location).Format(time.RFC3339)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		now      time.Time
+		title    string
+		due      string
+		rrule    string
+		priority string
+		hashtags []string
+		err      error
+	}{
+		{
+			name:     "request example",
+			input:    "Pay rent every 1st of the month at 9am #Personal !high",
+			title:    "Pay rent",
+			due:      at(time.April, 1, 9, 0),
+			rrule:    "FREQ=MONTHLY;BYMONTHDAY=1;BYHOUR=9;BYMINUTE=0",
+			priority: "high",
+			hashtags: []string{"Personal"},
+		},
+		{name: "tomorrow with a time", input: "Call mom tomorrow at 5pm", title: "Call mom", due: at(time.March, 7, 17, 0)},
+		{name: "time without at", input: "Pay bills 3pm", title: "Pay bills", due: at(time.March, 6, 15, 0)},
+		{name: "in hours", input: "Check oven in 2 hours", title: "Check oven", due: at(time.March, 6, 12, 0)},
+		{name: "next week", input: "Meeting next week", title: "Meeting", due: at(time.March, 9, 9, 0)},
+		{name: "month and day", input: "Dentist nov 5 at 2:30pm", title: "Dentist", due: at(time.November, 5, 14, 30)},
+		{name: "iso date", input: "Renew passport 2026-05-01", title: "Renew passport", due: at(time.May, 1, 9, 0)},
+		{name: "weekday across the DST change", input: "Water plants sunday", title: "Water plants", due: at(time.March, 8, 9, 0)},
+		{name: "days across the DST change", input: "Gym in 3 days", title: "Gym", due: at(time.March, 9, 9, 0)},
+		{name: "before the DST gap", input: "Feed cat on sunday at 1:30am", title: "Feed cat", due: at(time.March, 8, 1, 30)},
+		{
+			name:  "hours across the DST gap",
+			input: "Check heater in 2 hours",
+			now:   time.Date(2026, time.March, 8, 1, 30, 0, 0, location),
+			title: "Check heater",
+			due:   at(time.March, 8, 4, 30),
+		},
+		{
+			name:  "every weekday",
+			input: "Standup every weekday at 9:15am",
+			title: "Standup",
+			due:   at(time.March, 9, 9, 15),
+			rrule: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=15",
+		},
+		{
+			name:  "every n weeks on a weekday",
+			input: "Review every 2 weeks on friday",
+			title: "Review",
+			due:   at(time.March, 13, 9, 0),
+			rrule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR",
+		},
+		{name: "invalid month day", input: "Party feb 30", title: "Party feb 30"},
+		{name: "invalid iso date", input: "Party 2026-13-45", title: "Party 2026-13-45"},
+		{name: "ambiguous hour is the afternoon", input: "Call Bob at 3", title: "Call Bob", due: at(time.March, 6, 15, 0)},
+		{name: "hour with a leading zero", input: "Call Bob at 03:00", title: "Call Bob", due: at(time.March, 7, 3, 0)},
+		{name: "morning hour", input: "Call Bob at 9", title: "Call Bob", due: at(time.March, 7, 9, 0)},
+		{name: "no title", input: "tomorrow !high #Work", err: ErrNoTitle},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.now.IsZero() {
+				test.now = now
+			}
+
+			result, err := Parse(test.input, test.now)
+
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Parse(%q) error = %v, want %v", test.input, err, test.err)
+			}
+
+			if result.Title != test.title {
+				t.Errorf("title = %q, want %q", result.Title, test.title)
+			}
+
+			due := ""
+
+			if result.DueDate != nil {
+				due = result.DueDate.Format(time.RFC3339)
+			}
+
+			if due != test.due {
+				t.Errorf("due = %q, want %q", due, test.due)
+			}
+
+			if result.RRule != test.rrule {
+				t.Errorf("rrule = %q, want %q", result.RRule, test.rrule)
+			}
+
+			if result.Priority != test.priority {
+				t.Errorf("priority = %q, want %q", result.Priority, test.priority)
+			}
+
+			if !slices.Equal(result.Hashtags, test.hashtags) {
+				t.Errorf("hashtags = %v, want %v", result.Hashtags, test.hashtags)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type attachmentRepository interface {
+	FindByID(id int64) (models.Attachment, error)
+	FindByReminderID(reminderID int64) ([]models.Attachment, error)
+	FindByReminderIDs(reminderIDs []int64) ([]models.Attachment, error)
+	TotalSizeByUserID(userID int64) (int64, error)
+	CountByStorageKey(key string) (int64, error)
+	Create(attachment models.Attachment) (models.Attachment, error)
+	Delete(id int64) error
+}
+
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return AttachmentRepository{
+		db: db,
+	}
+}
+
+func (ar *AttachmentRepository) FindByID(id int64) (models.Attachment, error) {
+	var attachment models.Attachment
+	result := ar.db.First(&attachment, id)
+
+	return attachment, result.Error
+}
+
+func (ar *AttachmentRepository) FindByReminderID(reminderID int64) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	result := ar.db.Where("reminder_id = ?", reminderID).Order("created_at ASC, id ASC").Find(&attachments)
+
+	return attachments, result.Error
+}
+
+func (ar *AttachmentRepository) FindByReminderIDs(reminderIDs []int64) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+
+	if len(reminderIDs) == 0 {
+		return attachments, nil
+	}
+
+	result := ar.db.Where("reminder_id IN ?", reminderIDs).Find(&attachments)
+
+	return attachments, result.Error
+}
+
+// TotalSizeByUserID returns the number of bytes a user currently stores. A
+// blob shared by copies of an attachment only counts once.
+func (ar *AttachmentRepository) TotalSizeByUserID(userID int64) (int64, error) {
+	var total int64
+	blobs := ar.db.Model(&models.Attachment{}).Distinct("storage_key", "size").Where("user_id = ?", userID)
+	result := ar.db.Table("(?) AS blobs", blobs).Select("COALESCE(SUM(size), 0)").Scan(&total)
+
+	return total, result.Error
+}
+
+// CountByStorageKey returns how many attachments share a blob, which can
+// only be deleted once none are left.
+func (ar *AttachmentRepository) CountByStorageKey(key string) (int64, error) {
+	var count int64
+	result := ar.db.Model(&models.Attachment{}).Where("storage_key = ?", key).Count(&count)
+
+	return count, result.Error
+}
+
+func (ar *AttachmentRepository) Create(attachment models.Attachment) (models.Attachment, error) {
+	result := ar.db.Create(&attachment)
+
+	return attachment, result.Error
+}
+
+func (ar *AttachmentRepository) Delete(id int64) error {
+	result := ar.db.Delete(&models.Attachment{}, id)
+
+	return result.Error
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"errors"
 	"reminder-server/internal/models"
+	"reminder-server/internal/utils"
 
 	"gorm.io/gorm"
 )
@@ -11,6 +13,8 @@ type categoryRepository interface {
 	FindAllPaginated(limit int, offset int) (models.Pagination, error)
 	FindByID(id int64) (models.Category, error)
 	FindByUserID(userID int64) ([]models.Category, error)
+	FindChildren(id int64) ([]models.Category, error)
+	NameTaken(userID int64, name string, excludeID int64) (bool, error)
 	Create(category models.Category) (models.Category, error)
 	CreateBulk(categories []models.Category) ([]models.Category, error)
 	Update(category models.Category) (models.Category, error)
@@ -62,16 +66,51 @@ func (cr *CategoryRepository) FindByUserID(userID int64) ([]models.Category, err
 	return categories, result.Error
 }
 
+func (cr *CategoryRepository) FindChildren(id int64) ([]models.Category, error) {
+	var categories []models.Category
+	result := cr.db.Where("parent_id = ?", id).Find(&categories)
+
+	return categories, result.Error
+}
+
+// NameTaken reports whether another category of the user already has the
+// name, ignoring case. Categories in the trash don't count.
+func (cr *CategoryRepository) NameTaken(userID int64, name string, excludeID int64) (bool, error) {
+	var count int64
+	result := cr.db.Model(&models.Category{}).
+		Where("user_id = ? AND lower(name) = lower(?) AND id <> ?", userID, name, excludeID).
+		Count(&count)
+
+	return count > 0, result.Error
+}
+
 func (cr *CategoryRepository) Create(category models.Category) (models.Category, error) {
+	category.Version = 1
 	result := cr.db.Create(&category)
 
 	return category, result.Error
 }
 
+// Update saves the category only if its stored version is still the one it
+// was loaded with, bumping the version on success.
 func (cr *CategoryRepository) Update(category models.Category) (models.Category, error) {
-	result := cr.db.Save(&category)
+	err := cr.db.Transaction(func(tx *gorm.DB) error {
+		var before models.Category
 
-	return category, result.Error
+		if err := tx.First(&before, category.ID).Error; err != nil {
+			return err
+		}
+
+		if before.Version != category.Version {
+			return errors.New(utils.ErrorVersionConflict)
+		}
+
+		category.Version++
+
+		return tx.Save(&category).Error
+	})
+
+	return category, err
 }
 
 func (cr *CategoryRepository) Delete(id int64) error {
@@ -81,6 +120,10 @@ func (cr *CategoryRepository) Delete(id int64) error {
 }
 
 func (cr *CategoryRepository) CreateBulk(categories []models.Category) ([]models.Category, error) {
+	for i := range categories {
+		categories[i].Version = 1
+	}
+
 	result := cr.db.Create(&categories)
 
 	return categories, result.Error
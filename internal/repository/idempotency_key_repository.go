@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type idempotencyKeyRepository interface {
+	FindByKey(userID int64, key string) (models.IdempotencyKey, error)
+	Create(record models.IdempotencyKey) (models.IdempotencyKey, error)
+	Complete(record models.IdempotencyKey) error
+	Delete(id int64) error
+	DeleteCreatedBefore(before time.Time) (int64, error)
+}
+
+type IdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) IdempotencyKeyRepository {
+	return IdempotencyKeyRepository{
+		db: db,
+	}
+}
+
+func (ir *IdempotencyKeyRepository) FindByKey(userID int64, key string) (models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	result := ir.db.Where("user_id = ? AND key = ?", userID, key).First(&record)
+
+	return record, result.Error
+}
+
+// Create fails on the unique (user_id, key) index when another request
+// claimed the key first.
+func (ir *IdempotencyKeyRepository) Create(record models.IdempotencyKey) (models.IdempotencyKey, error) {
+	result := ir.db.Create(&record)
+
+	return record, result.Error
+}
+
+// Complete stores the response of the request that claimed the key.
+func (ir *IdempotencyKeyRepository) Complete(record models.IdempotencyKey) error {
+	result := ir.db.Model(&record).Select("status_code", "content_type", "headers", "body").Updates(&record)
+
+	return result.Error
+}
+
+func (ir *IdempotencyKeyRepository) Delete(id int64) error {
+	result := ir.db.Delete(&models.IdempotencyKey{}, id)
+
+	return result.Error
+}
+
+func (ir *IdempotencyKeyRepository) DeleteCreatedBefore(before time.Time) (int64, error) {
+	result := ir.db.Where("created_at < ?", before).Delete(&models.IdempotencyKey{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type reminderDependencyRepository interface {
+	FindBlockers(reminderID int64) ([]models.Reminder, error)
+	FindDependents(reminderID int64) ([]models.Reminder, error)
+	FindBlockerIDs(reminderIDs []int64) ([]models.ReminderDependency, error)
+	FindPendingBlockers(reminderIDs []int64) ([]models.ReminderDependency, error)
+	Exists(reminderID int64, blockedByID int64) (bool, error)
+	Create(dependency models.ReminderDependency) (models.ReminderDependency, error)
+	Delete(reminderID int64, blockedByID int64) error
+}
+
+type ReminderDependencyRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderDependencyRepository(db *gorm.DB) ReminderDependencyRepository {
+	return ReminderDependencyRepository{
+		db: db,
+	}
+}
+
+// FindBlockers returns the reminders that block the given one.
+func (dr *ReminderDependencyRepository) FindBlockers(reminderID int64) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	result := dr.db.Where(
+		"id IN (SELECT blocked_by_id FROM reminder_dependencies WHERE reminder_id = ?)", reminderID,
+	).Order("due_date ASC").Find(&reminders)
+
+	return reminders, result.Error
+}
+
+// FindDependents returns the reminders blocked by the given one.
+func (dr *ReminderDependencyRepository) FindDependents(reminderID int64) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	result := dr.db.Where(
+		"id IN (SELECT reminder_id FROM reminder_dependencies WHERE blocked_by_id = ?)", reminderID,
+	).Order("due_date ASC").Find(&reminders)
+
+	return reminders, result.Error
+}
+
+func (dr *ReminderDependencyRepository) FindBlockerIDs(reminderIDs []int64) ([]models.ReminderDependency, error) {
+	var dependencies []models.ReminderDependency
+	result := dr.db.Where("reminder_id IN ?", reminderIDs).Find(&dependencies)
+
+	return dependencies, result.Error
+}
+
+// FindPendingBlockers returns the dependencies of the given reminders whose
+// blocker isn't completed yet. Blockers in the trash no longer count.
+func (dr *ReminderDependencyRepository) FindPendingBlockers(reminderIDs []int64) ([]models.ReminderDependency, error) {
+	var dependencies []models.ReminderDependency
+
+	if len(reminderIDs) == 0 {
+		return dependencies, nil
+	}
+
+	result := dr.db.Table("reminder_dependencies").
+		Select("reminder_dependencies.reminder_id, reminder_dependencies.blocked_by_id").
+		Joins("JOIN reminders ON reminders.id = reminder_dependencies.blocked_by_id").
+		Where("reminder_dependencies.reminder_id IN ? AND reminders.status != ? AND reminders.deleted_at IS NULL", reminderIDs, models.StatusCompleted).
+		Scan(&dependencies)
+
+	return dependencies, result.Error
+}
+
+func (dr *ReminderDependencyRepository) Exists(reminderID int64, blockedByID int64) (bool, error) {
+	var count int64
+	result := dr.db.Model(&models.ReminderDependency{}).
+		Where("reminder_id = ? AND blocked_by_id = ?", reminderID, blockedByID).
+		Count(&count)
+
+	return count > 0, result.Error
+}
+
+func (dr *ReminderDependencyRepository) Create(dependency models.ReminderDependency) (models.ReminderDependency, error) {
+	result := dr.db.Create(&dependency)
+
+	return dependency, result.Error
+}
+
+func (dr *ReminderDependencyRepository) Delete(reminderID int64, blockedByID int64) error {
+	result := dr.db.Where("reminder_id = ? AND blocked_by_id = ?", reminderID, blockedByID).
+		Delete(&models.ReminderDependency{})
+
+	return result.Error
+}
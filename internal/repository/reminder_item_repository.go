@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type reminderItemRepository interface {
+	FindByID(id int64) (models.ReminderItem, error)
+	FindByReminderID(reminderID int64) ([]models.ReminderItem, error)
+	CountProgress(reminderIDs []int64) ([]models.ReminderProgress, error)
+	Create(item models.ReminderItem) (models.ReminderItem, error)
+	Update(item models.ReminderItem) (models.ReminderItem, error)
+	UpdatePositions(itemIDs []int64) error
+	ShiftPositions(reminderID int64, from int) error
+	Delete(id int64) error
+}
+
+type ReminderItemRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderItemRepository(db *gorm.DB) ReminderItemRepository {
+	return ReminderItemRepository{
+		db: db,
+	}
+}
+
+func (ir *ReminderItemRepository) FindByID(id int64) (models.ReminderItem, error) {
+	var item models.ReminderItem
+	result := ir.db.First(&item, id)
+
+	return item, result.Error
+}
+
+func (ir *ReminderItemRepository) FindByReminderID(reminderID int64) ([]models.ReminderItem, error) {
+	var items []models.ReminderItem
+	result := ir.db.Where("reminder_id = ?", reminderID).Order("position ASC, id ASC").Find(&items)
+
+	return items, result.Error
+}
+
+// CountProgress returns total and done item counts for each reminder that has
+// at least one item.
+func (ir *ReminderItemRepository) CountProgress(reminderIDs []int64) ([]models.ReminderProgress, error) {
+	var progress []models.ReminderProgress
+
+	if len(reminderIDs) == 0 {
+		return progress, nil
+	}
+
+	result := ir.db.Model(&models.ReminderItem{}).
+		Select("reminder_id, COUNT(*) AS total, SUM(CASE WHEN done THEN 1 ELSE 0 END) AS done").
+		Where("reminder_id IN ?", reminderIDs).
+		Group("reminder_id").
+		Scan(&progress)
+
+	return progress, result.Error
+}
+
+func (ir *ReminderItemRepository) Create(item models.ReminderItem) (models.ReminderItem, error) {
+	result := ir.db.Create(&item)
+
+	return item, result.Error
+}
+
+func (ir *ReminderItemRepository) Update(item models.ReminderItem) (models.ReminderItem, error) {
+	result := ir.db.Save(&item)
+
+	return item, result.Error
+}
+
+// UpdatePositions stores the index of each item ID as its position.
+func (ir *ReminderItemRepository) UpdatePositions(itemIDs []int64) error {
+	return ir.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range itemIDs {
+			err := tx.Model(&models.ReminderItem{}).Where("id = ?", id).Update("position", position).Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ShiftPositions moves every item at or after the given position down by one
+// to make room for an insert.
+func (ir *ReminderItemRepository) ShiftPositions(reminderID int64, from int) error {
+	result := ir.db.Model(&models.ReminderItem{}).
+		Where("reminder_id = ? AND position >= ?", reminderID, from).
+		Update("position", gorm.Expr("position + 1"))
+
+	return result.Error
+}
+
+func (ir *ReminderItemRepository) Delete(id int64) error {
+	result := ir.db.Delete(&models.ReminderItem{}, id)
+
+	return result.Error
+}
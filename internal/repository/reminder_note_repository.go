@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type reminderNoteRepository interface {
+	FindByID(id int64) (models.ReminderNote, error)
+	FindByReminderID(reminderID int64) ([]models.ReminderNote, error)
+	FindRevisions(noteID int64) ([]models.ReminderNoteRevision, error)
+	Create(note models.ReminderNote) (models.ReminderNote, error)
+	Update(note models.ReminderNote, previousBody string) (models.ReminderNote, error)
+	Delete(id int64) error
+}
+
+type ReminderNoteRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderNoteRepository(db *gorm.DB) ReminderNoteRepository {
+	return ReminderNoteRepository{
+		db: db,
+	}
+}
+
+func (nr *ReminderNoteRepository) FindByID(id int64) (models.ReminderNote, error) {
+	var note models.ReminderNote
+	result := nr.db.First(&note, id)
+
+	return note, result.Error
+}
+
+func (nr *ReminderNoteRepository) FindByReminderID(reminderID int64) ([]models.ReminderNote, error) {
+	var notes []models.ReminderNote
+	result := nr.db.Where("reminder_id = ?", reminderID).Order("created_at ASC, id ASC").Find(&notes)
+
+	return notes, result.Error
+}
+
+func (nr *ReminderNoteRepository) FindRevisions(noteID int64) ([]models.ReminderNoteRevision, error) {
+	var revisions []models.ReminderNoteRevision
+	result := nr.db.Where("note_id = ?", noteID).Order("created_at DESC, id DESC").Find(&revisions)
+
+	return revisions, result.Error
+}
+
+func (nr *ReminderNoteRepository) Create(note models.ReminderNote) (models.ReminderNote, error) {
+	result := nr.db.Create(&note)
+
+	return note, result.Error
+}
+
+// Update saves the new body of a note and keeps the previous one as a revision.
+func (nr *ReminderNoteRepository) Update(note models.ReminderNote, previousBody string) (models.ReminderNote, error) {
+	err := nr.db.Transaction(func(tx *gorm.DB) error {
+		revision := models.ReminderNoteRevision{
+			NoteID: note.ID,
+			Body:   previousBody,
+		}
+
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Revisions").Save(&note).Error
+	})
+
+	return note, err
+}
+
+func (nr *ReminderNoteRepository) Delete(id int64) error {
+	return nr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("note_id = ?", id).Delete(&models.ReminderNoteRevision{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.ReminderNote{}, id).Error
+	})
+}
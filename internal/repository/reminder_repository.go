@@ -1,20 +1,54 @@
 package repository
 
 import (
+	"errors"
 	"reminder-server/internal/models"
+	"reminder-server/internal/ordering"
+	"reminder-server/internal/utils"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type reminderRepository interface {
 	FindAll() ([]models.Reminder, error)
 	FindByID(id int64) (models.Reminder, error)
 	FindByUserID(userID int64) ([]models.Reminder, error)
+	FindByFilter(userID int64, filter models.ReminderFilter) ([]models.Reminder, error)
+	FindByCategoryID(categoryID int64) ([]models.Reminder, error)
+	CountPending(categoryID int64) (int64, error)
 	Create(reminder models.Reminder) (models.Reminder, error)
 	Update(reminder models.Reminder) (models.Reminder, error)
+	UpdateAs(reminder models.Reminder, action string) (models.Reminder, error)
+	UpdateWithTags(reminder models.Reminder, tagIDs []int64, action string) (models.Reminder, error)
 	Delete(id int64) error
+	DeleteIfVersion(id int64, version int64) error
+	ReplaceTags(reminder models.Reminder, tags []models.Tag) error
+	AddTags(reminder models.Reminder, tags []models.Tag) error
+	RemoveTags(reminder models.Reminder, tags []models.Tag) error
+	ArchiveCompletedBefore(before time.Time, archivedAt time.Time) (int64, error)
+	LastPosition(categoryID int64, excludeID int64) (string, error)
+	AdjacentPosition(categoryID int64, position string, excludeID int64, after bool) (string, error)
+	SpreadPositions(categoryID int64) error
 }
 
+// reminderSortOrders maps the accepted sort keys to their ORDER BY clause.
+// Only keys present here can ever reach the query.
+var reminderSortOrders = map[string]string{
+	"":            "due_date ASC",
+	"due_date":    "due_date ASC",
+	"-due_date":   "due_date DESC",
+	"priority":    "CASE priority WHEN 'high' THEN 0 WHEN 'medium' THEN 1 ELSE 2 END, due_date ASC",
+	"created_at":  "created_at ASC",
+	"-created_at": "created_at DESC",
+	"title":       "title COLLATE NOCASE ASC",
+	"manual":      "category_id ASC, position ASC, id ASC",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type ReminderRepository struct {
 	db *gorm.DB
 }
@@ -38,7 +72,7 @@ func (rr *ReminderRepository) FindAll() ([]models.Reminder, error) {
 
 func (rr *ReminderRepository) FindByID(id int64) (models.Reminder, error) {
 	var reminder models.Reminder
-	result := rr.db.First(&reminder, id)
+	result := rr.db.Preload("Tags").First(&reminder, id)
 
 	return reminder, result.Error
 }
@@ -46,26 +80,409 @@ func (rr *ReminderRepository) FindByID(id int64) (models.Reminder, error) {
 func (rr *ReminderRepository) FindByUserID(userID int64) ([]models.Reminder, error) {
 	var reminders []models.Reminder
 	result := rr.db.Where("user_id = ?", userID).Order(
-		"due_date ASC",
+		"pinned DESC, due_date ASC",
 	).Find(&reminders)
 
 	return reminders, result.Error
 }
 
+func (rr *ReminderRepository) FindByFilter(userID int64, filter models.ReminderFilter) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+
+	order, ok := reminderSortOrders[filter.Sort]
+
+	if !ok {
+		order = reminderSortOrders[""]
+	}
+
+	query := rr.db.Preload("Tags").Where("user_id = ?", userID)
+
+	if !filter.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if filter.Priority != "" {
+		query = query.Where("priority = ?", filter.Priority)
+	}
+
+	if filter.CategoryID != nil && filter.IncludeDescendants {
+		query = query.Where(`category_id IN (
+			WITH RECURSIVE subtree(id) AS (
+				SELECT ? UNION SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id
+			)
+			SELECT id FROM subtree
+		)`, *filter.CategoryID)
+	} else if filter.CategoryID != nil {
+		query = query.Where("category_id = ?", *filter.CategoryID)
+	}
+
+	if filter.IsRecurring != nil {
+		query = query.Where("is_recurring = ?", *filter.IsRecurring)
+	}
+
+	if filter.Flagged != nil {
+		query = query.Where("flagged = ?", *filter.Flagged)
+	}
+
+	if filter.Search != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+		query = query.Where(`(title LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\')`, pattern, pattern)
+	}
+
+	if len(filter.TagIDs) > 0 {
+		if filter.TagMatch == models.TagMatchAll {
+			query = query.Where(
+				"id IN (SELECT reminder_id FROM reminder_tags WHERE tag_id IN ? GROUP BY reminder_id HAVING COUNT(DISTINCT tag_id) = ?)",
+				filter.TagIDs, len(uniqueIDs(filter.TagIDs)),
+			)
+		} else {
+			query = query.Where("id IN (SELECT reminder_id FROM reminder_tags WHERE tag_id IN ?)", filter.TagIDs)
+		}
+	}
+
+	if filter.DueFrom != nil {
+		query = query.Where("due_date >= ?", *filter.DueFrom)
+	}
+
+	if filter.DueTo != nil {
+		query = query.Where("due_date < ?", *filter.DueTo)
+	}
+
+	// Pinned reminders come first whatever the sort
+	result := query.Order("pinned DESC").Order(order).Find(&reminders)
+
+	return reminders, result.Error
+}
+
+// FindByCategoryID returns the category's reminders, archived ones included,
+// in their manual order.
+func (rr *ReminderRepository) FindByCategoryID(categoryID int64) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	result := rr.db.Where("category_id = ?", categoryID).Order("position ASC, id ASC").Find(&reminders)
+
+	return reminders, result.Error
+}
+
+// CountPending counts the category's pending reminders that aren't archived.
+func (rr *ReminderRepository) CountPending(categoryID int64) (int64, error) {
+	var count int64
+	result := rr.db.Model(&models.Reminder{}).
+		Where("category_id = ? AND status = ? AND archived_at IS NULL", categoryID, models.StatusPending).
+		Count(&count)
+
+	return count, result.Error
+}
+
+// Create, Update and Delete record a revision of the change in the same
+// transaction, so the history can't miss a write. Create attaches the
+// reminder's Tags in that transaction as well.
 func (rr *ReminderRepository) Create(reminder models.Reminder) (models.Reminder, error) {
-	result := rr.db.Create(&reminder)
+	reminder.Version = 1
 
-	return reminder, result.Error
+	err := rr.db.Transaction(func(tx *gorm.DB) error {
+		tags := reminder.Tags
+
+		if err := tx.Omit(clause.Associations).Create(&reminder).Error; err != nil {
+			return err
+		}
+
+		if len(tags) > 0 {
+			if err := tx.Model(&reminder).Association("Tags").Replace(tags); err != nil {
+				return err
+			}
+		}
+
+		return recordRevision(tx, models.RevisionActionCreate, &reminder.UserID, nil, &reminder)
+	})
+
+	return reminder, err
 }
 
+// Update saves the reminder's own columns. Tags are changed through the
+// dedicated tag methods so a stale Tags slice can't re-attach removed tags.
 func (rr *ReminderRepository) Update(reminder models.Reminder) (models.Reminder, error) {
-	result := rr.db.Save(&reminder)
+	return rr.UpdateAs(reminder, "")
+}
 
-	return reminder, result.Error
+// UpdateAs saves the reminder like Update, recording the revision under the
+// given action instead of one worked out from the changed fields. The save is
+// refused if the stored version moved on since the reminder was loaded.
+func (rr *ReminderRepository) UpdateAs(reminder models.Reminder, action string) (models.Reminder, error) {
+	return rr.update(reminder, nil, action)
+}
+
+// UpdateWithTags saves the reminder like UpdateAs and sets its tags to the
+// given ones, recording both in one revision. Revert and undo use it to bring
+// back recorded tags. Tags deleted since are skipped, and nil IDs leave the
+// tags as they are.
+func (rr *ReminderRepository) UpdateWithTags(reminder models.Reminder, tagIDs []int64, action string) (models.Reminder, error) {
+	return rr.update(reminder, tagIDs, action)
+}
+
+func (rr *ReminderRepository) update(reminder models.Reminder, tagIDs []int64, action string) (models.Reminder, error) {
+	err := rr.db.Transaction(func(tx *gorm.DB) error {
+		var before models.Reminder
+
+		if err := tx.First(&before, reminder.ID).Error; err != nil {
+			return err
+		}
+
+		if before.Version != reminder.Version {
+			return errors.New(utils.ErrorVersionConflict)
+		}
+
+		beforeTagIDs, err := reminderTagIDs(tx, reminder.ID)
+
+		if err != nil {
+			return err
+		}
+
+		reminder.Version++
+
+		if err := tx.Omit(clause.Associations).Save(&reminder).Error; err != nil {
+			return err
+		}
+
+		afterTagIDs := beforeTagIDs
+
+		if tagIDs != nil {
+			if err := tx.Exec("DELETE FROM reminder_tags WHERE reminder_id = ?", reminder.ID).Error; err != nil {
+				return err
+			}
+
+			if len(tagIDs) > 0 {
+				err := tx.Exec("INSERT INTO reminder_tags (reminder_id, tag_id) SELECT ?, id FROM tags WHERE id IN ?", reminder.ID, tagIDs).Error
+
+				if err != nil {
+					return err
+				}
+			}
+
+			if afterTagIDs, err = reminderTagIDs(tx, reminder.ID); err != nil {
+				return err
+			}
+		}
+
+		return recordTaggedRevision(tx, action, &reminder.UserID, &before, beforeTagIDs, &reminder, afterTagIDs)
+	})
+
+	return reminder, err
 }
 
 func (rr *ReminderRepository) Delete(id int64) error {
-	result := rr.db.Delete(&models.Reminder{}, id)
+	return rr.delete(id, nil)
+}
+
+// DeleteIfVersion deletes the reminder like Delete, refusing to if its
+// stored version isn't the given one anymore.
+func (rr *ReminderRepository) DeleteIfVersion(id int64, version int64) error {
+	return rr.delete(id, &version)
+}
+
+func (rr *ReminderRepository) delete(id int64, version *int64) error {
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		var before models.Reminder
+
+		if err := tx.First(&before, id).Error; err != nil {
+			return err
+		}
+
+		if version != nil && before.Version != *version {
+			return errors.New(utils.ErrorVersionConflict)
+		}
+
+		if err := tx.Delete(&models.Reminder{}, id).Error; err != nil {
+			return err
+		}
+
+		// A timer left running in the trash would keep the user from
+		// starting another one, and can't be stopped there
+		timeEntryRepo := NewTimeEntryRepository(tx)
+
+		if err := timeEntryRepo.StopRunning(id, utils.GetCurrentTime()); err != nil {
+			return err
+		}
+
+		return recordRevision(tx, models.RevisionActionDelete, &before.UserID, &before, nil)
+	})
+}
+
+// The tag methods bump the reminder's version as well, since the tags are
+// part of the representation its ETag stands for, and record a revision of
+// the change.
+func (rr *ReminderRepository) ReplaceTags(reminder models.Reminder, tags []models.Tag) error {
+	return rr.changeTags(reminder, func(association *gorm.Association) error {
+		return association.Replace(tags)
+	})
+}
+
+func (rr *ReminderRepository) AddTags(reminder models.Reminder, tags []models.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return rr.changeTags(reminder, func(association *gorm.Association) error {
+		return association.Append(tags)
+	})
+}
+
+func (rr *ReminderRepository) RemoveTags(reminder models.Reminder, tags []models.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return rr.changeTags(reminder, func(association *gorm.Association) error {
+		return association.Delete(tags)
+	})
+}
+
+func (rr *ReminderRepository) changeTags(reminder models.Reminder, change func(association *gorm.Association) error) error {
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Reminder
+
+		if err := tx.First(&current, reminder.ID).Error; err != nil {
+			return err
+		}
+
+		beforeTagIDs, err := reminderTagIDs(tx, reminder.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if err := change(tx.Model(&reminder).Association("Tags")); err != nil {
+			return err
+		}
+
+		afterTagIDs, err := reminderTagIDs(tx, reminder.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Reminder{}).Where("id = ?", reminder.ID).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error; err != nil {
+			return err
+		}
+
+		return recordTaggedRevision(tx, "", &current.UserID, &current, beforeTagIDs, &current, afterTagIDs)
+	})
+}
+
+// ArchiveCompletedBefore archives every reminder that was completed before the
+// given time and isn't archived yet, returning how many were archived. The
+// revisions have no actor since no user made the change.
+func (rr *ReminderRepository) ArchiveCompletedBefore(before time.Time, archivedAt time.Time) (int64, error) {
+	var reminders []models.Reminder
+
+	err := rr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("status = ? AND archived_at IS NULL AND completed_at < ?", models.StatusCompleted, before).
+			Find(&reminders).Error
+
+		if err != nil || len(reminders) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(reminders))
+
+		for i, reminder := range reminders {
+			ids[i] = reminder.ID
+		}
+
+		if err := tx.Model(&models.Reminder{}).Where("id IN ?", ids).Updates(map[string]any{
+			"archived_at": archivedAt,
+			"version":     gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+
+		for _, reminder := range reminders {
+			archived := reminder
+			archived.ArchivedAt = &archivedAt
+
+			if err := recordRevision(tx, models.RevisionActionArchive, nil, &reminder, &archived); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return int64(len(reminders)), err
+}
+
+// The position lookups include trashed reminders, so a restored reminder
+// never shares its key with one placed while it was away.
+
+// LastPosition returns the highest position in the category, or an empty
+// string when it has no other reminders.
+func (rr *ReminderRepository) LastPosition(categoryID int64, excludeID int64) (string, error) {
+	var position string
+	result := rr.db.Unscoped().Model(&models.Reminder{}).
+		Select("COALESCE(MAX(position), '')").
+		Where("category_id = ? AND id <> ?", categoryID, excludeID).
+		Scan(&position)
+
+	return position, result.Error
+}
+
+// AdjacentPosition returns the position right after or right before the
+// given one in the category, or an empty string at either end.
+func (rr *ReminderRepository) AdjacentPosition(categoryID int64, position string, excludeID int64, after bool) (string, error) {
+	query := rr.db.Unscoped().Model(&models.Reminder{}).Where("category_id = ? AND id <> ?", categoryID, excludeID)
+
+	if after {
+		query = query.Select("COALESCE(MIN(position), '')").Where("position > ?", position)
+	} else {
+		query = query.Select("COALESCE(MAX(position), '')").Where("position < ?", position)
+	}
+
+	var adjacent string
+	result := query.Scan(&adjacent)
+
+	return adjacent, result.Error
+}
+
+// SpreadPositions renumbers the category with evenly spaced keys, keeping
+// the order. It's only needed once keys have grown long.
+func (rr *ReminderRepository) SpreadPositions(categoryID int64) error {
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		var ids []int64
+
+		err := tx.Unscoped().Model(&models.Reminder{}).
+			Where("category_id = ?", categoryID).
+			Order("position ASC, id ASC").
+			Pluck("id", &ids).Error
+
+		if err != nil {
+			return err
+		}
+
+		for i, position := range ordering.Spread(len(ids)) {
+			err := tx.Unscoped().Model(&models.Reminder{}).Where("id = ?", ids[i]).Updates(map[string]any{
+				"position": position,
+				"version":  gorm.Expr("version + 1"),
+			}).Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func uniqueIDs(ids []int64) map[int64]bool {
+	unique := make(map[int64]bool, len(ids))
+
+	for _, id := range ids {
+		unique[id] = true
+	}
 
-	return result.Error
+	return unique
 }
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"encoding/json"
+	"reflect"
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type reminderRevisionRepository interface {
+	FindByID(id int64) (models.ReminderRevision, error)
+	FindByReminderID(reminderID int64) ([]models.ReminderRevision, error)
+	FindLatest(reminderID int64) (models.ReminderRevision, error)
+}
+
+type ReminderRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRevisionRepository(db *gorm.DB) ReminderRevisionRepository {
+	return ReminderRevisionRepository{
+		db: db,
+	}
+}
+
+func (rr *ReminderRevisionRepository) FindByID(id int64) (models.ReminderRevision, error) {
+	var revision models.ReminderRevision
+	result := rr.db.First(&revision, id)
+
+	return revision, result.Error
+}
+
+func (rr *ReminderRevisionRepository) FindByReminderID(reminderID int64) ([]models.ReminderRevision, error) {
+	var revisions []models.ReminderRevision
+	result := rr.db.Where("reminder_id = ?", reminderID).Order("id DESC").Find(&revisions)
+
+	return revisions, result.Error
+}
+
+func (rr *ReminderRevisionRepository) FindLatest(reminderID int64) (models.ReminderRevision, error) {
+	var revision models.ReminderRevision
+	result := rr.db.Where("reminder_id = ?", reminderID).Order("id DESC").First(&revision)
+
+	return revision, result.Error
+}
+
+// recordRevision stores the change between two states of a reminder, either
+// of which is nil for creations and deletions. Updates that change none of
+// the tracked fields are not recorded. An empty action is worked out from
+// the changed fields. Both states get the reminder's current tags.
+func recordRevision(tx *gorm.DB, action string, actorID *int64, before *models.Reminder, after *models.Reminder) error {
+	var reminderID int64
+
+	if after != nil {
+		reminderID = after.ID
+	} else {
+		reminderID = before.ID
+	}
+
+	tagIDs, err := reminderTagIDs(tx, reminderID)
+
+	if err != nil {
+		return err
+	}
+
+	return recordTaggedRevision(tx, action, actorID, before, tagIDs, after, tagIDs)
+}
+
+// recordTaggedRevision records a revision like recordRevision for a change
+// that also took the reminder's tags from beforeTagIDs to afterTagIDs.
+func recordTaggedRevision(tx *gorm.DB, action string, actorID *int64, before *models.Reminder, beforeTagIDs []int64, after *models.Reminder, afterTagIDs []int64) error {
+	revision := models.ReminderRevision{
+		Action:  action,
+		ActorID: actorID,
+		Before:  snapshotReminder(before, beforeTagIDs),
+		After:   snapshotReminder(after, afterTagIDs),
+	}
+
+	if after != nil {
+		revision.ReminderID = after.ID
+	} else {
+		revision.ReminderID = before.ID
+	}
+
+	changes, err := diffSnapshots(revision.Before, revision.After)
+
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 && before != nil && after != nil {
+		return nil
+	}
+
+	revision.Changes = changes
+
+	if revision.Action == "" {
+		revision.Action = inferRevisionAction(changes)
+	}
+
+	return tx.Create(&revision).Error
+}
+
+// reminderTagIDs returns the IDs of a reminder's tags in ascending order.
+func reminderTagIDs(tx *gorm.DB, reminderID int64) ([]int64, error) {
+	tagIDs := []int64{}
+	result := tx.Table("reminder_tags").Where("reminder_id = ?", reminderID).Order("tag_id").Pluck("tag_id", &tagIDs)
+
+	return tagIDs, result.Error
+}
+
+func snapshotReminder(reminder *models.Reminder, tagIDs []int64) *models.ReminderSnapshot {
+	if reminder == nil {
+		return nil
+	}
+
+	return &models.ReminderSnapshot{
+		Title:            reminder.Title,
+		Description:      reminder.Description,
+		CategoryID:       reminder.CategoryID,
+		DueDate:          reminder.DueDate,
+		StartDate:        reminder.StartDate,
+		EstimatedMinutes: reminder.EstimatedMinutes,
+		Priority:         reminder.Priority,
+		Status:           reminder.Status,
+		IsRecurring:      reminder.IsRecurring,
+		RecurringPattern: reminder.RecurringPattern,
+		AutoComplete:     reminder.AutoComplete,
+		Pinned:           reminder.Pinned,
+		Flagged:          reminder.Flagged,
+		CompletedAt:      reminder.CompletedAt,
+		ArchivedAt:       reminder.ArchivedAt,
+		Position:         reminder.Position,
+		TagIDs:           tagIDs,
+	}
+}
+
+// diffSnapshots compares two snapshots field by field, using their JSON
+// form so the recorded values match what the API returns.
+func diffSnapshots(before *models.ReminderSnapshot, after *models.ReminderSnapshot) (map[string]models.ReminderFieldChange, error) {
+	beforeFields, err := snapshotFields(before)
+
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := snapshotFields(after)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]models.ReminderFieldChange{}
+
+	for name := range mergeKeys(beforeFields, afterFields) {
+		if !reflect.DeepEqual(beforeFields[name], afterFields[name]) {
+			changes[name] = models.ReminderFieldChange{From: beforeFields[name], To: afterFields[name]}
+		}
+	}
+
+	return changes, nil
+}
+
+func snapshotFields(snapshot *models.ReminderSnapshot) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+
+	if snapshot == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(snapshot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &fields)
+
+	return fields, err
+}
+
+func mergeKeys(a map[string]interface{}, b map[string]interface{}) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+
+	for key := range a {
+		keys[key] = true
+	}
+
+	for key := range b {
+		keys[key] = true
+	}
+
+	return keys
+}
+
+// inferRevisionAction labels an update by what it changed: completing or
+// reopening is a status change and only touching archived_at is archiving.
+func inferRevisionAction(changes map[string]models.ReminderFieldChange) string {
+	if _, ok := changes["archived_at"]; ok && len(changes) == 1 {
+		return models.RevisionActionArchive
+	}
+
+	if _, ok := changes["status"]; !ok {
+		return models.RevisionActionUpdate
+	}
+
+	for name := range changes {
+		if name != "status" && name != "completed_at" && name != "archived_at" {
+			return models.RevisionActionUpdate
+		}
+	}
+
+	return models.RevisionActionStatus
+}
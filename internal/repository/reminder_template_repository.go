@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type reminderTemplateRepository interface {
+	FindByID(id int64) (models.ReminderTemplate, error)
+	FindByUserID(userID int64) ([]models.ReminderTemplate, error)
+	FindByCategoryID(categoryID int64) ([]models.ReminderTemplate, error)
+	Create(template models.ReminderTemplate) (models.ReminderTemplate, error)
+	Update(template models.ReminderTemplate) (models.ReminderTemplate, error)
+	Delete(id int64) error
+	Trash(id int64) error
+}
+
+type ReminderTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderTemplateRepository(db *gorm.DB) ReminderTemplateRepository {
+	return ReminderTemplateRepository{
+		db: db,
+	}
+}
+
+func (tr *ReminderTemplateRepository) FindByID(id int64) (models.ReminderTemplate, error) {
+	var template models.ReminderTemplate
+	result := tr.db.First(&template, id)
+
+	return template, result.Error
+}
+
+func (tr *ReminderTemplateRepository) FindByUserID(userID int64) ([]models.ReminderTemplate, error) {
+	var templates []models.ReminderTemplate
+	result := tr.db.Where("user_id = ?", userID).Order("name ASC").Find(&templates)
+
+	return templates, result.Error
+}
+
+func (tr *ReminderTemplateRepository) FindByCategoryID(categoryID int64) ([]models.ReminderTemplate, error) {
+	var templates []models.ReminderTemplate
+	result := tr.db.Where("category_id = ?", categoryID).Find(&templates)
+
+	return templates, result.Error
+}
+
+func (tr *ReminderTemplateRepository) Create(template models.ReminderTemplate) (models.ReminderTemplate, error) {
+	result := tr.db.Create(&template)
+
+	return template, result.Error
+}
+
+func (tr *ReminderTemplateRepository) Update(template models.ReminderTemplate) (models.ReminderTemplate, error) {
+	result := tr.db.Save(&template)
+
+	return template, result.Error
+}
+
+func (tr *ReminderTemplateRepository) Delete(id int64) error {
+	result := tr.db.Unscoped().Delete(&models.ReminderTemplate{}, id)
+
+	return result.Error
+}
+
+// Trash moves a template to the trash along with its category. It comes back
+// when the category is restored.
+func (tr *ReminderTemplateRepository) Trash(id int64) error {
+	result := tr.db.Delete(&models.ReminderTemplate{}, id)
+
+	return result.Error
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type savedFilterRepository interface {
+	FindByID(id int64) (models.SavedFilter, error)
+	FindByUserID(userID int64) ([]models.SavedFilter, error)
+	Create(savedFilter models.SavedFilter) (models.SavedFilter, error)
+	Update(savedFilter models.SavedFilter) (models.SavedFilter, error)
+	Delete(id int64) error
+}
+
+type SavedFilterRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedFilterRepository(db *gorm.DB) SavedFilterRepository {
+	return SavedFilterRepository{
+		db: db,
+	}
+}
+
+func (sr *SavedFilterRepository) FindByID(id int64) (models.SavedFilter, error) {
+	var savedFilter models.SavedFilter
+	result := sr.db.First(&savedFilter, id)
+
+	return savedFilter, result.Error
+}
+
+func (sr *SavedFilterRepository) FindByUserID(userID int64) ([]models.SavedFilter, error) {
+	var savedFilters []models.SavedFilter
+	result := sr.db.Where("user_id = ?", userID).Order("name ASC").Find(&savedFilters)
+
+	return savedFilters, result.Error
+}
+
+func (sr *SavedFilterRepository) Create(savedFilter models.SavedFilter) (models.SavedFilter, error) {
+	result := sr.db.Create(&savedFilter)
+
+	return savedFilter, result.Error
+}
+
+func (sr *SavedFilterRepository) Update(savedFilter models.SavedFilter) (models.SavedFilter, error) {
+	result := sr.db.Save(&savedFilter)
+
+	return savedFilter, result.Error
+}
+
+func (sr *SavedFilterRepository) Delete(id int64) error {
+	result := sr.db.Delete(&models.SavedFilter{}, id)
+
+	return result.Error
+}
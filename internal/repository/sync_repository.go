@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type syncRepository interface {
+	FindChanges(userID int64, since int64, limit int) ([]models.ChangeLogEntry, error)
+	FindEntityChanges(entityType string, entityID int64, after int64, upTo int64) ([]models.ChangeLogEntry, error)
+	LatestChangeID() (int64, error)
+	FindReminders(ids []int64) ([]models.Reminder, error)
+	FindCategories(ids []int64) ([]models.Category, error)
+	FindItems(ids []int64) ([]models.ReminderItem, error)
+	FindNotes(ids []int64) ([]models.ReminderNote, error)
+	FindAttachments(ids []int64) ([]models.Attachment, error)
+	FindTags(ids []int64) ([]models.Tag, error)
+}
+
+// SyncRepository reads the change log, which is written by database
+// triggers, and loads the current state of the entities it points at.
+type SyncRepository struct {
+	db *gorm.DB
+}
+
+func NewSyncRepository(db *gorm.DB) SyncRepository {
+	return SyncRepository{
+		db: db,
+	}
+}
+
+// FindChanges returns up to limit entries of the user after the since token,
+// oldest first.
+func (sr *SyncRepository) FindChanges(userID int64, since int64, limit int) ([]models.ChangeLogEntry, error) {
+	var entries []models.ChangeLogEntry
+	result := sr.db.Where("user_id = ? AND id > ?", userID, since).Order("id ASC").Limit(limit).Find(&entries)
+
+	return entries, result.Error
+}
+
+// FindEntityChanges returns the entries of one entity in the (after, upTo]
+// range of tokens, oldest first.
+func (sr *SyncRepository) FindEntityChanges(entityType string, entityID int64, after int64, upTo int64) ([]models.ChangeLogEntry, error) {
+	var entries []models.ChangeLogEntry
+	result := sr.db.Where("entity_type = ? AND entity_id = ? AND id > ? AND id <= ?", entityType, entityID, after, upTo).
+		Order("id ASC").
+		Find(&entries)
+
+	return entries, result.Error
+}
+
+func (sr *SyncRepository) LatestChangeID() (int64, error) {
+	var id int64
+	result := sr.db.Model(&models.ChangeLogEntry{}).Select("COALESCE(MAX(id), 0)").Scan(&id)
+
+	return id, result.Error
+}
+
+// FindReminders leaves out trashed reminders, which sync as deleted.
+func (sr *SyncRepository) FindReminders(ids []int64) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	result := sr.db.Preload("Tags").Where("id IN ?", ids).Find(&reminders)
+
+	return reminders, result.Error
+}
+
+func (sr *SyncRepository) FindCategories(ids []int64) ([]models.Category, error) {
+	var categories []models.Category
+	result := sr.db.Where("id IN ?", ids).Find(&categories)
+
+	return categories, result.Error
+}
+
+func (sr *SyncRepository) FindItems(ids []int64) ([]models.ReminderItem, error) {
+	var items []models.ReminderItem
+	result := sr.db.Where("id IN ?", ids).Find(&items)
+
+	return items, result.Error
+}
+
+func (sr *SyncRepository) FindNotes(ids []int64) ([]models.ReminderNote, error) {
+	var notes []models.ReminderNote
+	result := sr.db.Where("id IN ?", ids).Find(&notes)
+
+	return notes, result.Error
+}
+
+func (sr *SyncRepository) FindAttachments(ids []int64) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	result := sr.db.Where("id IN ?", ids).Find(&attachments)
+
+	return attachments, result.Error
+}
+
+func (sr *SyncRepository) FindTags(ids []int64) ([]models.Tag, error) {
+	var tags []models.Tag
+	result := sr.db.Where("id IN ?", ids).Find(&tags)
+
+	return tags, result.Error
+}
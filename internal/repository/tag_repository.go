@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type tagRepository interface {
+	FindByID(id int64) (models.Tag, error)
+	FindByIDs(userID int64, ids []int64) ([]models.Tag, error)
+	FindByUserID(userID int64) ([]models.Tag, error)
+	FindByName(userID int64, name string) (models.Tag, error)
+	Create(tag models.Tag) (models.Tag, error)
+	Update(tag models.Tag) (models.Tag, error)
+	Delete(id int64) error
+}
+
+// tagUsageSelect adds the number of reminders carrying each tag, leaving out
+// reminders in the trash.
+const tagUsageSelect = `tags.*, (
+	SELECT COUNT(*) FROM reminder_tags
+	JOIN reminders ON reminders.id = reminder_tags.reminder_id AND reminders.deleted_at IS NULL
+	WHERE reminder_tags.tag_id = tags.id
+) AS usage_count`
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return TagRepository{
+		db: db,
+	}
+}
+
+func (tr *TagRepository) FindByID(id int64) (models.Tag, error) {
+	var tag models.Tag
+	result := tr.db.Select(tagUsageSelect).First(&tag, id)
+
+	return tag, result.Error
+}
+
+func (tr *TagRepository) FindByIDs(userID int64, ids []int64) ([]models.Tag, error) {
+	var tags []models.Tag
+
+	if len(ids) == 0 {
+		return tags, nil
+	}
+
+	result := tr.db.Where("user_id = ? AND id IN ?", userID, ids).Find(&tags)
+
+	return tags, result.Error
+}
+
+func (tr *TagRepository) FindByUserID(userID int64) ([]models.Tag, error) {
+	var tags []models.Tag
+	result := tr.db.Select(tagUsageSelect).Where("user_id = ?", userID).Order("name ASC").Find(&tags)
+
+	return tags, result.Error
+}
+
+// FindByName looks up a tag by name, ignoring case.
+func (tr *TagRepository) FindByName(userID int64, name string) (models.Tag, error) {
+	var tag models.Tag
+	result := tr.db.Where("user_id = ? AND name = ? COLLATE NOCASE", userID, name).First(&tag)
+
+	return tag, result.Error
+}
+
+func (tr *TagRepository) Create(tag models.Tag) (models.Tag, error) {
+	result := tr.db.Create(&tag)
+
+	return tag, result.Error
+}
+
+func (tr *TagRepository) Update(tag models.Tag) (models.Tag, error) {
+	result := tr.db.Save(&tag)
+
+	return tag, result.Error
+}
+
+// Delete removes a tag and detaches it from every reminder.
+func (tr *TagRepository) Delete(id int64) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM reminder_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.Tag{}, id).Error
+	})
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type timeEntryRepository interface {
+	FindByID(id int64) (models.TimeEntry, error)
+	FindByReminderID(reminderID int64) ([]models.TimeEntry, error)
+	FindRunning(userID int64) (models.TimeEntry, error)
+	StopRunning(reminderID int64, now time.Time) error
+	Create(entry models.TimeEntry) (models.TimeEntry, error)
+	Update(entry models.TimeEntry) (models.TimeEntry, error)
+	Delete(id int64) error
+	SumReminder(reminderID int64) (models.TimeSummary, int64, error)
+	SumCategory(userID int64, categoryID int64) (models.TimeSummary, int64, error)
+}
+
+type TimeEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) TimeEntryRepository {
+	return TimeEntryRepository{
+		db: db,
+	}
+}
+
+func (tr *TimeEntryRepository) FindByID(id int64) (models.TimeEntry, error) {
+	var entry models.TimeEntry
+	result := tr.db.First(&entry, id)
+
+	return entry, result.Error
+}
+
+func (tr *TimeEntryRepository) FindByReminderID(reminderID int64) ([]models.TimeEntry, error) {
+	var entries []models.TimeEntry
+	result := tr.db.Where("reminder_id = ?", reminderID).Order("started_at DESC, id DESC").Find(&entries)
+
+	return entries, result.Error
+}
+
+// FindRunning returns the user's running timer, of which there is at most one.
+func (tr *TimeEntryRepository) FindRunning(userID int64) (models.TimeEntry, error) {
+	var entry models.TimeEntry
+	result := tr.db.Where("user_id = ? AND ended_at IS NULL", userID).First(&entry)
+
+	return entry, result.Error
+}
+
+// StopRunning ends a timer still running on the reminder at the given time.
+func (tr *TimeEntryRepository) StopRunning(reminderID int64, now time.Time) error {
+	var entries []models.TimeEntry
+
+	if err := tr.db.Where("reminder_id = ? AND ended_at IS NULL", reminderID).Find(&entries).Error; err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		entry.EndedAt = &now
+		entry.DurationSeconds = int64(now.Sub(entry.StartedAt).Seconds())
+
+		if err := tr.db.Save(&entry).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tr *TimeEntryRepository) Create(entry models.TimeEntry) (models.TimeEntry, error) {
+	result := tr.db.Create(&entry)
+
+	return entry, result.Error
+}
+
+func (tr *TimeEntryRepository) Update(entry models.TimeEntry) (models.TimeEntry, error) {
+	result := tr.db.Save(&entry)
+
+	return entry, result.Error
+}
+
+func (tr *TimeEntryRepository) Delete(id int64) error {
+	result := tr.db.Delete(&models.TimeEntry{}, id)
+
+	return result.Error
+}
+
+// SumReminder returns the estimate and the number of entries of a reminder,
+// along with the seconds of its finished entries.
+func (tr *TimeEntryRepository) SumReminder(reminderID int64) (models.TimeSummary, int64, error) {
+	var summary models.TimeSummary
+
+	err := tr.db.Model(&models.Reminder{}).
+		Select("COALESCE(estimated_minutes, 0)").
+		Where("id = ?", reminderID).
+		Scan(&summary.EstimatedMinutes).Error
+
+	if err != nil {
+		return summary, 0, err
+	}
+
+	seconds, err := tr.sumEntries(tr.db.Where("reminder_id = ?", reminderID), &summary)
+
+	return summary, seconds, err
+}
+
+// SumCategory is SumReminder over every reminder of the user in a category.
+// Reminders in the trash are left out.
+func (tr *TimeEntryRepository) SumCategory(userID int64, categoryID int64) (models.TimeSummary, int64, error) {
+	var summary models.TimeSummary
+
+	reminders := tr.db.Model(&models.Reminder{}).
+		Where("user_id = ? AND category_id = ?", userID, categoryID).
+		Session(&gorm.Session{})
+
+	err := reminders.
+		Select("COALESCE(SUM(estimated_minutes), 0)").
+		Scan(&summary.EstimatedMinutes).Error
+
+	if err != nil {
+		return summary, 0, err
+	}
+
+	seconds, err := tr.sumEntries(tr.db.Where("reminder_id IN (?)", reminders.Select("id")), &summary)
+
+	return summary, seconds, err
+}
+
+func (tr *TimeEntryRepository) sumEntries(query *gorm.DB, summary *models.TimeSummary) (int64, error) {
+	var totals struct {
+		Count   int64
+		Seconds int64
+	}
+
+	err := query.Model(&models.TimeEntry{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(CASE WHEN ended_at IS NULL THEN 0 ELSE duration_seconds END), 0) AS seconds").
+		Scan(&totals).Error
+
+	summary.EntryCount = totals.Count
+
+	return totals.Seconds, err
+}
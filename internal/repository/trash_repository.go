@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"reminder-server/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type trashRepository interface {
+	FindReminders(userID int64) ([]models.Reminder, error)
+	FindCategories(userID int64) ([]models.Category, error)
+	FindReminder(userID int64, id int64) (models.Reminder, error)
+	FindCategory(userID int64, id int64) (models.Category, error)
+	FindExpiredReminderIDs(deletedBefore time.Time) ([]int64, error)
+	FindExpiredCategoryIDs(deletedBefore time.Time) ([]int64, error)
+	RestoreReminder(id int64) error
+	RestoreCategory(id int64) error
+	PurgeReminders(ids []int64) error
+	PurgeCategories(ids []int64) error
+}
+
+// restoredColumns brings a row back from the trash. The version is bumped so
+// clients holding the deleted row's ETag see the change.
+var restoredColumns = map[string]any{
+	"deleted_at": nil,
+	"version":    gorm.Expr("version + 1"),
+}
+
+// TrashRepository works on soft-deleted reminders and categories, which the
+// other repositories never see.
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return TrashRepository{
+		db: db,
+	}
+}
+
+func (tr *TrashRepository) FindReminders(userID int64) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	result := tr.db.Unscoped().Preload("Tags").
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
+		Find(&reminders)
+
+	return reminders, result.Error
+}
+
+func (tr *TrashRepository) FindCategories(userID int64) ([]models.Category, error) {
+	var categories []models.Category
+	result := tr.db.Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
+		Find(&categories)
+
+	return categories, result.Error
+}
+
+func (tr *TrashRepository) FindReminder(userID int64, id int64) (models.Reminder, error) {
+	var reminder models.Reminder
+	result := tr.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).First(&reminder, id)
+
+	return reminder, result.Error
+}
+
+func (tr *TrashRepository) FindCategory(userID int64, id int64) (models.Category, error) {
+	var category models.Category
+	result := tr.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).First(&category, id)
+
+	return category, result.Error
+}
+
+func (tr *TrashRepository) FindExpiredReminderIDs(deletedBefore time.Time) ([]int64, error) {
+	var ids []int64
+	result := tr.db.Unscoped().Model(&models.Reminder{}).Where("deleted_at < ?", deletedBefore).Pluck("id", &ids)
+
+	return ids, result.Error
+}
+
+func (tr *TrashRepository) FindExpiredCategoryIDs(deletedBefore time.Time) ([]int64, error) {
+	var ids []int64
+	result := tr.db.Unscoped().Model(&models.Category{}).Where("deleted_at < ?", deletedBefore).Pluck("id", &ids)
+
+	return ids, result.Error
+}
+
+func (tr *TrashRepository) RestoreReminder(id int64) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Reminder{}).Where("id = ?", id).Updates(restoredColumns).Error; err != nil {
+			return err
+		}
+
+		// The revision shows the reminder as it is once restored
+		var reminder models.Reminder
+
+		if err := tx.First(&reminder, id).Error; err != nil {
+			return err
+		}
+
+		return recordRevision(tx, models.RevisionActionRestore, &reminder.UserID, nil, &reminder)
+	})
+}
+
+// RestoreCategory brings back a category together with the templates that
+// went to the trash with it.
+func (tr *TrashRepository) RestoreCategory(id int64) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Category{}).Where("id = ?", id).Updates(restoredColumns).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&models.ReminderTemplate{}).
+			Where("category_id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil).Error
+	})
+}
+
+// PurgeReminders permanently deletes reminders together with everything that
+// hangs off them. Attachment blobs must be removed by the caller.
+func (tr *TrashRepository) PurgeReminders(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			"DELETE FROM reminder_note_revisions WHERE note_id IN (SELECT id FROM reminder_notes WHERE reminder_id IN ?)",
+			"DELETE FROM reminder_notes WHERE reminder_id IN ?",
+			"DELETE FROM reminder_items WHERE reminder_id IN ?",
+			"DELETE FROM reminder_tags WHERE reminder_id IN ?",
+			"DELETE FROM attachments WHERE reminder_id IN ?",
+			"DELETE FROM reminder_revisions WHERE reminder_id IN ?",
+			"DELETE FROM time_entries WHERE reminder_id IN ?",
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement, ids).Error; err != nil {
+				return err
+			}
+		}
+
+		// Dependencies can point at a purged reminder from either side
+		if err := tx.Exec("DELETE FROM reminder_dependencies WHERE reminder_id IN ? OR blocked_by_id IN ?", ids, ids).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&models.Reminder{}, ids).Error
+	})
+}
+
+// PurgeCategories permanently deletes categories along with their trashed
+// templates, skipping any that reminders, templates or subcategories still
+// point at. Those stay in the trash. A category whose subcategories are purged
+// along with it goes in a later pass.
+func (tr *TrashRepository) PurgeCategories(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := tr.db.Exec("DELETE FROM reminder_templates WHERE category_id IN ? AND deleted_at IS NOT NULL", ids).Error; err != nil {
+		return err
+	}
+
+	for {
+		result := tr.db.Unscoped().
+			Where("id NOT IN (SELECT category_id FROM reminders WHERE category_id IS NOT NULL)").
+			Where("id NOT IN (SELECT category_id FROM reminder_templates)").
+			Where("id NOT IN (SELECT parent_id FROM categories WHERE parent_id IS NOT NULL)").
+			Delete(&models.Category{}, ids)
+
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+	}
+}
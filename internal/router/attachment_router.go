@@ -0,0 +1,18 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAttachmentRouter(router *gin.Engine, attachmentHandler *handlers.AttachmentHandler) {
+	attachments := router.Group("/reminders/:id/attachments")
+
+	attachments.GET("/", attachmentHandler.List)
+	attachments.GET("/:attachment_id", attachmentHandler.Download)
+
+	attachments.POST("/", attachmentHandler.Upload)
+
+	attachments.DELETE("/:attachment_id", attachmentHandler.Delete)
+}
@@ -13,6 +13,8 @@ func SetupCategoryRouter(router *gin.Engine, categoryHandler *handlers.CategoryH
 	categories.GET("/:id", categoryHandler.Get)
 
 	categories.POST("/", categoryHandler.Create)
+	categories.POST("/:id/move", categoryHandler.Move)
+	categories.POST("/:id/merge", categoryHandler.Merge)
 
 	categories.PATCH("/:id", categoryHandler.Update)
 
@@ -0,0 +1,12 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupDuplicateRouter(router *gin.Engine, duplicateHandler *handlers.DuplicateHandler) {
+	router.POST("/reminders/:id/duplicate", duplicateHandler.DuplicateReminder)
+	router.POST("/categories/:id/duplicate", duplicateHandler.DuplicateCategory)
+}
@@ -0,0 +1,11 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupQuickAddRouter(router *gin.Engine, quickAddHandler *handlers.QuickAddHandler) {
+	router.POST("/reminders/quick", quickAddHandler.Add)
+}
@@ -0,0 +1,11 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReminderBulkRouter(router *gin.Engine, reminderBulkHandler *handlers.ReminderBulkHandler) {
+	router.POST("/reminders/bulk", reminderBulkHandler.Apply)
+}
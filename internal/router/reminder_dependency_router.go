@@ -0,0 +1,17 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReminderDependencyRouter(router *gin.Engine, dependencyHandler *handlers.ReminderDependencyHandler) {
+	dependencies := router.Group("/reminders/:id/dependencies")
+
+	dependencies.GET("/", dependencyHandler.List)
+
+	dependencies.POST("/", dependencyHandler.Add)
+
+	dependencies.DELETE("/:blocked_by_id", dependencyHandler.Remove)
+}
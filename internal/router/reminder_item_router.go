@@ -0,0 +1,21 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReminderItemRouter(router *gin.Engine, reminderItemHandler *handlers.ReminderItemHandler) {
+	items := router.Group("/reminders/:id/items")
+
+	items.GET("/", reminderItemHandler.List)
+
+	items.POST("/", reminderItemHandler.Create)
+
+	items.PUT("/order", reminderItemHandler.Reorder)
+
+	items.PATCH("/:item_id", reminderItemHandler.Update)
+
+	items.DELETE("/:item_id", reminderItemHandler.Delete)
+}
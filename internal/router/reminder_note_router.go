@@ -0,0 +1,20 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReminderNoteRouter(router *gin.Engine, reminderNoteHandler *handlers.ReminderNoteHandler) {
+	notes := router.Group("/reminders/:id/notes")
+
+	notes.GET("/", reminderNoteHandler.List)
+	notes.GET("/:note_id/history", reminderNoteHandler.History)
+
+	notes.POST("/", reminderNoteHandler.Create)
+
+	notes.PATCH("/:note_id", reminderNoteHandler.Update)
+
+	notes.DELETE("/:note_id", reminderNoteHandler.Delete)
+}
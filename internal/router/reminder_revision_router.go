@@ -0,0 +1,16 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReminderRevisionRouter(router *gin.Engine, revisionHandler *handlers.ReminderRevisionHandler) {
+	reminders := router.Group("/reminders/:id")
+
+	reminders.GET("/history", revisionHandler.History)
+
+	reminders.POST("/revert/:revision", revisionHandler.Revert)
+	reminders.POST("/undo", revisionHandler.Undo)
+}
@@ -10,12 +10,17 @@ func SetupReminderRouter(router *gin.Engine, reminderHandler *handlers.ReminderH
 	reminders := router.Group("/reminders")
 
 	reminders.GET("/", reminderHandler.List)
+	reminders.GET("/views/flagged", reminderHandler.ListFlagged)
 	reminders.GET("/:id", reminderHandler.Get)
 
 	reminders.POST("/", reminderHandler.Create)
 
 	reminders.PUT("/:id/status", reminderHandler.UpdateStatus)
 
+	reminders.POST("/:id/archive", reminderHandler.Archive)
+	reminders.POST("/:id/unarchive", reminderHandler.Unarchive)
+	reminders.POST("/:id/move", reminderHandler.Move)
+
 	reminders.PATCH("/:id", reminderHandler.Update)
 
 	reminders.DELETE("/:id", reminderHandler.Delete)
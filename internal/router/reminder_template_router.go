@@ -0,0 +1,22 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReminderTemplateRouter(router *gin.Engine, templateHandler *handlers.ReminderTemplateHandler) {
+	templates := router.Group("/reminder-templates")
+
+	templates.GET("/", templateHandler.List)
+	templates.GET("/:id", templateHandler.Get)
+
+	templates.POST("/", templateHandler.Create)
+
+	templates.PATCH("/:id", templateHandler.Update)
+
+	templates.DELETE("/:id", templateHandler.Delete)
+
+	router.POST("/reminders/from-template/:id", templateHandler.Instantiate)
+}
@@ -2,15 +2,33 @@ package router
 
 import (
 	"reminder-server/internal/initializers"
+	"reminder-server/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 func SetupRouter(router *gin.Engine, in initializers.Initializers) *gin.Engine {
+	// Registered first so it wraps every route set up below
+	router.Use(middleware.Idempotency(in.IdempotencyService))
+
 	SetupHealthRouter(router, in.HealthHandler)
 	SetupCategoryRouter(router, in.CategoryHandler)
 	SetupReminderRouter(router, in.ReminderHandler)
+	SetupReminderItemRouter(router, in.ReminderItemHandler)
+	SetupReminderNoteRouter(router, in.ReminderNoteHandler)
+	SetupAttachmentRouter(router, in.AttachmentHandler)
+	SetupTrashRouter(router, in.TrashHandler)
+	SetupReminderBulkRouter(router, in.ReminderBulkHandler)
+	SetupReminderTemplateRouter(router, in.TemplateHandler)
+	SetupQuickAddRouter(router, in.QuickAddHandler)
+	SetupReminderDependencyRouter(router, in.DependencyHandler)
+	SetupReminderRevisionRouter(router, in.RevisionHandler)
+	SetupSyncRouter(router, in.SyncHandler)
+	SetupTimeEntryRouter(router, in.TimeEntryHandler)
+	SetupDuplicateRouter(router, in.DuplicateHandler)
 	SetupUserRouter(router, in.UserHandler)
+	SetupSavedFilterRouter(router, in.SavedFilterHandler)
+	SetupTagRouter(router, in.TagHandler)
 
 	return router
 }
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSavedFilterRouter(router *gin.Engine, savedFilterHandler *handlers.SavedFilterHandler) {
+	savedFilters := router.Group("/saved-filters")
+
+	savedFilters.GET("/", savedFilterHandler.List)
+	savedFilters.GET("/:id", savedFilterHandler.Get)
+	savedFilters.GET("/:id/reminders", savedFilterHandler.Reminders)
+
+	savedFilters.POST("/", savedFilterHandler.Create)
+
+	savedFilters.PATCH("/:id", savedFilterHandler.Update)
+
+	savedFilters.DELETE("/:id", savedFilterHandler.Delete)
+}
@@ -0,0 +1,12 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSyncRouter(router *gin.Engine, syncHandler *handlers.SyncHandler) {
+	router.GET("/sync", syncHandler.Pull)
+	router.POST("/sync", syncHandler.Push)
+}
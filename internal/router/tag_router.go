@@ -0,0 +1,20 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTagRouter(router *gin.Engine, tagHandler *handlers.TagHandler) {
+	tags := router.Group("/tags")
+
+	tags.GET("/", tagHandler.List)
+	tags.GET("/:id", tagHandler.Get)
+
+	tags.POST("/", tagHandler.Create)
+
+	tags.PATCH("/:id", tagHandler.Update)
+
+	tags.DELETE("/:id", tagHandler.Delete)
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTimeEntryRouter(router *gin.Engine, timeEntryHandler *handlers.TimeEntryHandler) {
+	reminder := router.Group("/reminders/:id")
+
+	reminder.GET("/time", timeEntryHandler.ReminderSummary)
+
+	reminder.POST("/timer/start", timeEntryHandler.StartTimer)
+	reminder.POST("/timer/stop", timeEntryHandler.StopTimer)
+
+	entries := reminder.Group("/time-entries")
+
+	entries.GET("/", timeEntryHandler.List)
+
+	entries.POST("/", timeEntryHandler.Create)
+
+	entries.DELETE("/:entry_id", timeEntryHandler.Delete)
+
+	router.GET("/categories/:id/time", timeEntryHandler.CategorySummary)
+}
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"reminder-server/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTrashRouter(router *gin.Engine, trashHandler *handlers.TrashHandler) {
+	trash := router.Group("/trash")
+
+	trash.GET("/", trashHandler.List)
+
+	trash.DELETE("/", trashHandler.Empty)
+
+	router.POST("/reminders/:id/restore", trashHandler.RestoreReminder)
+	router.POST("/categories/:id/restore", trashHandler.RestoreCategory)
+}
@@ -12,6 +12,8 @@ func SetupUserRouter(router *gin.Engine, userHandler *handlers.UserHandler) {
 	users.GET("/", userHandler.List)
 	users.GET("/:id", userHandler.Get)
 
+	users.PATCH("/me", userHandler.UpdateMe)
+
 	users.POST("/signup", userHandler.SignUp)
 	users.POST("/login", userHandler.Login)
 }
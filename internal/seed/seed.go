@@ -0,0 +1,87 @@
+// Package seed holds the data every new user starts out with.
+package seed
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reminder-server/internal/utils"
+	"strings"
+)
+
+// Category is a category created for every new user
+type Category struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+	Icon  string `json:"icon"`
+}
+
+//go:embed categories.json
+var defaultCategoriesFile []byte
+
+var defaultCategories []Category
+
+// LoadCategories reads a seed set from a JSON file holding an array of
+// categories. An empty path loads the built-in set.
+func LoadCategories(path string) ([]Category, error) {
+	data := defaultCategoriesFile
+
+	if path != "" {
+		var err error
+
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	var categories []Category
+
+	if err := json.Unmarshal(data, &categories); err != nil {
+		return nil, fmt.Errorf("invalid seed categories: %w", err)
+	}
+
+	names := make(map[string]bool, len(categories))
+
+	for _, category := range categories {
+		name := strings.ToLower(strings.TrimSpace(category.Name))
+
+		if name == "" {
+			return nil, errors.New("invalid seed categories: a category has no name")
+		}
+
+		if names[name] {
+			return nil, fmt.Errorf("invalid seed categories: %q is listed twice", category.Name)
+		}
+
+		names[name] = true
+
+		if category.Color != "" && !utils.IsValidHexColor(category.Color) {
+			return nil, fmt.Errorf("invalid seed categories: %q has an invalid color", category.Name)
+		}
+
+		if category.Icon != "" && !utils.IsValidCategoryIcon(category.Icon) {
+			return nil, fmt.Errorf("invalid seed categories: %q has an invalid icon", category.Name)
+		}
+	}
+
+	return categories, nil
+}
+
+// SetDefaultCategories registers the seed set used when users sign up.
+func SetDefaultCategories(categories []Category) {
+	defaultCategories = categories
+}
+
+// DefaultCategories returns the seed set registered with
+// SetDefaultCategories, or the built-in one.
+func DefaultCategories() []Category {
+	if defaultCategories == nil {
+		categories, _ := LoadCategories("")
+
+		return categories
+	}
+
+	return defaultCategories
+}
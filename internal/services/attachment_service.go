@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/storage"
+	"reminder-server/internal/utils"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAttachmentMaxSize   = 10 << 20
+	defaultAttachmentUserQuota = 100 << 20
+)
+
+// allowedAttachmentTypes lists the sniffed content types accepted for upload.
+// Entries ending in a slash match a whole family of types.
+var allowedAttachmentTypes = []string{
+	"image/",
+	"application/pdf",
+	"text/plain",
+	"application/zip",
+}
+
+type AttachmentService struct {
+	repo            repository.AttachmentRepository
+	reminderService *ReminderService
+	store           storage.BlobStore
+	maxSize         int64
+	userQuota       int64
+}
+
+func NewAttachmentService(db *gorm.DB) *AttachmentService {
+	return &AttachmentService{
+		repo:            repository.NewAttachmentRepository(db),
+		reminderService: NewReminderService(db),
+		store:           storage.Default(),
+		maxSize:         utils.GetEnvInt64("ATTACHMENT_MAX_BYTES", defaultAttachmentMaxSize),
+		userQuota:       utils.GetEnvInt64("ATTACHMENT_QUOTA_BYTES", defaultAttachmentUserQuota),
+	}
+}
+
+// MaxSize is the largest file accepted for upload, in bytes.
+func (as *AttachmentService) MaxSize() int64 {
+	return as.maxSize
+}
+
+func (as *AttachmentService) List(userID int64, reminderID int64) ([]models.Attachment, error) {
+	if _, err := as.reminderService.GetForUser(userID, reminderID); err != nil {
+		return []models.Attachment{}, err
+	}
+
+	attachments, err := as.repo.FindByReminderID(reminderID)
+
+	if err != nil {
+		return []models.Attachment{}, err
+	}
+
+	return attachments, nil
+}
+
+// Upload stores a file for a reminder. The content is spooled to a temporary
+// file first so size, type and quota can be checked before it reaches the
+// blob store.
+func (as *AttachmentService) Upload(ctx context.Context, userID int64, reminderID int64, fileName string, r io.Reader) (models.Attachment, error) {
+	if as.store == nil {
+		return models.Attachment{}, errors.New(utils.ErrorStorageNotConfigured)
+	}
+
+	if _, err := as.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.Attachment{}, err
+	}
+
+	spool, err := os.CreateTemp("", "attachment-*")
+
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	size, err := io.Copy(spool, io.LimitReader(r, as.maxSize+1))
+
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	if size > as.maxSize {
+		return models.Attachment{}, errors.New(utils.ErrorAttachmentTooLarge)
+	}
+
+	if size == 0 {
+		return models.Attachment{}, errors.New(utils.ErrorAttachmentEmpty)
+	}
+
+	head := make([]byte, 512)
+	n, err := spool.ReadAt(head, 0)
+
+	if err != nil && err != io.EOF {
+		return models.Attachment{}, err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+
+	if !isAllowedAttachmentType(contentType) {
+		return models.Attachment{}, errors.New(utils.ErrorAttachmentType)
+	}
+
+	used, err := as.repo.TotalSizeByUserID(userID)
+
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	if used+size > as.userQuota {
+		return models.Attachment{}, errors.New(utils.ErrorAttachmentQuota)
+	}
+
+	key, err := newStorageKey(userID)
+
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return models.Attachment{}, err
+	}
+
+	if err := as.store.Put(ctx, key, spool, size, contentType); err != nil {
+		return models.Attachment{}, err
+	}
+
+	attachment, err := as.repo.Create(models.Attachment{
+		ReminderID:  reminderID,
+		UserID:      userID,
+		FileName:    sanitizeFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	})
+
+	if err != nil {
+		if deleteErr := as.store.Delete(ctx, key); deleteErr != nil {
+			log.Printf("Error removing blob %s after failed upload: %v", key, deleteErr)
+		}
+
+		return models.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+// Open returns an attachment together with a reader over its content. The
+// caller must close the reader.
+func (as *AttachmentService) Open(ctx context.Context, userID int64, reminderID int64, attachmentID int64) (models.Attachment, io.ReadSeekCloser, error) {
+	if as.store == nil {
+		return models.Attachment{}, nil, errors.New(utils.ErrorStorageNotConfigured)
+	}
+
+	attachment, err := as.get(userID, reminderID, attachmentID)
+
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	blob, err := as.store.Open(ctx, attachment.StorageKey)
+
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return models.Attachment{}, nil, errors.New(utils.ErrorAttachmentNotFound)
+	}
+
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	return attachment, blob, nil
+}
+
+func (as *AttachmentService) Delete(ctx context.Context, userID int64, reminderID int64, attachmentID int64) error {
+	attachment, err := as.get(userID, reminderID, attachmentID)
+
+	if err != nil {
+		return err
+	}
+
+	if err := as.repo.Delete(attachment.ID); err != nil {
+		return err
+	}
+
+	if as.store == nil {
+		return nil
+	}
+
+	// Duplicated reminders share the blob of the original
+	if count, err := as.repo.CountByStorageKey(attachment.StorageKey); err != nil || count > 0 {
+		return err
+	}
+
+	return as.store.Delete(ctx, attachment.StorageKey)
+}
+
+func (as *AttachmentService) get(userID int64, reminderID int64, attachmentID int64) (models.Attachment, error) {
+	if _, err := as.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.Attachment{}, err
+	}
+
+	attachment, err := as.repo.FindByID(attachmentID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && attachment.ReminderID != reminderID) {
+		return models.Attachment{}, errors.New(utils.ErrorAttachmentNotFound)
+	}
+
+	return attachment, err
+}
+
+func isAllowedAttachmentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
+	for _, allowed := range allowedAttachmentTypes {
+		if mediaType == allowed || (strings.HasSuffix(allowed, "/") && strings.HasPrefix(mediaType, allowed)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newStorageKey returns a random blob key grouped by user.
+func newStorageKey(userID int64) (string, error) {
+	random := make([]byte, 16)
+
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d/%s", userID, hex.EncodeToString(random)), nil
+}
+
+// sanitizeFileName keeps the base name of an uploaded file without control
+// characters so it can be echoed back in a Content-Disposition header.
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+
+		return r
+	}, name)
+
+	if len(name) > 255 {
+		name = name[:255]
+	}
+
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+
+	return name
+}
@@ -1,25 +1,48 @@
 package services
 
 import (
+	"errors"
 	"log"
 	"reminder-server/internal/models"
 	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+	"slices"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+const defaultCategoryMaxDepth = 5
+
 type CategoryService struct {
-	repo repository.CategoryRepository
+	db        *gorm.DB
+	repo      repository.CategoryRepository
+	maxDepth  int
+	ifVersion *int64
 }
 
 func NewCategoryService(db *gorm.DB) *CategoryService {
 	return &CategoryService{
-		repo: repository.NewCategoryRepository(db),
+		db:       db,
+		repo:     repository.NewCategoryRepository(db),
+		maxDepth: int(utils.GetEnvInt64("CATEGORY_MAX_DEPTH", defaultCategoryMaxDepth)),
 	}
 }
 
-func (cs *CategoryService) List(userId int32) ([]models.Category, error) {
-	categories, err := cs.repo.FindByUserID(int64(userId))
+// IfVersion returns a copy of the service that only changes a category
+// while its stored version still is the given one. The version is compared
+// in the transaction making the change, so a change landing after the
+// request's If-Match was checked isn't overwritten.
+func (cs *CategoryService) IfVersion(version int64) *CategoryService {
+	versioned := *cs
+	versioned.ifVersion = &version
+
+	return &versioned
+}
+
+func (cs *CategoryService) List(userID int64) ([]models.Category, error) {
+	categories, err := cs.repo.FindByUserID(userID)
 
 	if err != nil {
 		return []models.Category{}, err
@@ -28,6 +51,38 @@ func (cs *CategoryService) List(userId int32) ([]models.Category, error) {
 	return categories, nil
 }
 
+// Tree returns the user's top-level categories with their subcategories
+// nested under them.
+func (cs *CategoryService) Tree(userID int64) ([]models.Category, error) {
+	categories, err := cs.List(userID)
+
+	if err != nil {
+		return []models.Category{}, err
+	}
+
+	children := childrenByParent(categories)
+
+	var build func(parentID int64) []models.Category
+
+	build = func(parentID int64) []models.Category {
+		nodes := children[parentID]
+
+		for i := range nodes {
+			nodes[i].Children = build(nodes[i].ID)
+		}
+
+		return nodes
+	}
+
+	roots := build(0)
+
+	if roots == nil {
+		return []models.Category{}, nil
+	}
+
+	return roots, nil
+}
+
 func (cs *CategoryService) Get(id int64) (models.Category, error) {
 	category, err := cs.repo.FindByID(id)
 
@@ -38,13 +93,43 @@ func (cs *CategoryService) Get(id int64) (models.Category, error) {
 	return category, nil
 }
 
-func (cs *CategoryService) Create(request models.CategoryCreateRequest) (models.Category, error) {
+// GetForUser returns the category only if it belongs to the user, reporting
+// other users' categories as not found.
+func (cs *CategoryService) GetForUser(userID int64, id int64) (models.Category, error) {
+	category, err := cs.Get(id)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && category.UserID != userID) {
+		return models.Category{}, errors.New(utils.ErrorCategoryNotFound)
+	}
+
+	return category, err
+}
+
+func (cs *CategoryService) Create(userID int64, request models.CategoryCreateRequest) (models.Category, error) {
 	log.Println("Creating category")
 
 	newCategory := models.Category{
-		Name:  request.Name,
-		Color: request.Color,
-		Icon:  request.Icon,
+		Name:            strings.TrimSpace(request.Name),
+		Color:           request.Color,
+		Icon:            request.Icon,
+		ParentID:        request.ParentID,
+		DefaultPriority: request.DefaultPriority,
+		DefaultDueTime:  request.DefaultDueTime,
+		DefaultTagIDs:   request.DefaultTagIDs,
+		WIPLimit:        request.WIPLimit,
+		UserID:          userID,
+	}
+
+	if newCategory.DefaultTagIDs == nil {
+		newCategory.DefaultTagIDs = []int64{}
+	}
+
+	if err := cs.checkParent(newCategory); err != nil {
+		return models.Category{}, err
+	}
+
+	if err := cs.validate(newCategory); err != nil {
+		return models.Category{}, err
 	}
 
 	category, err := cs.repo.Create(newCategory)
@@ -52,21 +137,22 @@ func (cs *CategoryService) Create(request models.CategoryCreateRequest) (models.
 	return category, err
 }
 
-func (cs *CategoryService) Update(id int64, request models.CategoryUpdateRequest) (models.Category, error) {
+func (cs *CategoryService) Update(userID int64, id int64, request models.CategoryUpdateRequest) (models.Category, error) {
 	log.Println("Updating category")
 
-	currentCategory, err := cs.Get(id)
+	currentCategory, err := cs.GetForUser(userID, id)
 
 	if err != nil {
 		return models.Category{}, err
 	}
 
-	if request.Name == nil && request.Color == nil && request.Icon == nil {
+	if request.Name == nil && request.Color == nil && request.Icon == nil && request.DefaultPriority == nil &&
+		request.DefaultDueTime == nil && request.DefaultTagIDs == nil && request.WIPLimit == nil {
 		return currentCategory, nil
 	}
 
 	if request.Name != nil {
-		currentCategory.Name = *request.Name
+		currentCategory.Name = strings.TrimSpace(*request.Name)
 	}
 
 	if request.Color != nil {
@@ -77,6 +163,28 @@ func (cs *CategoryService) Update(id int64, request models.CategoryUpdateRequest
 		currentCategory.Icon = *request.Icon
 	}
 
+	if request.DefaultPriority != nil {
+		currentCategory.DefaultPriority = *request.DefaultPriority
+	}
+
+	if request.DefaultDueTime != nil {
+		currentCategory.DefaultDueTime = *request.DefaultDueTime
+	}
+
+	if request.DefaultTagIDs != nil {
+		currentCategory.DefaultTagIDs = *request.DefaultTagIDs
+	}
+
+	if request.WIPLimit != nil {
+		currentCategory.WIPLimit = *request.WIPLimit
+	}
+
+	if err := cs.validate(currentCategory); err != nil {
+		return models.Category{}, err
+	}
+
+	cs.expectVersion(&currentCategory)
+
 	category, err := cs.repo.Update(currentCategory)
 
 	if err != nil {
@@ -86,16 +194,479 @@ func (cs *CategoryService) Update(id int64, request models.CategoryUpdateRequest
 	return category, nil
 }
 
-func (cs *CategoryService) Delete(id int64) error {
+// Move places a category under another parent or at the top level,
+// together with its subcategories.
+func (cs *CategoryService) Move(userID int64, id int64, request models.CategoryMoveRequest) (models.Category, error) {
+	log.Println("Moving category")
+
+	category, err := cs.GetForUser(userID, id)
+
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	category.ParentID = request.ParentID
+
+	if err := cs.checkParent(category); err != nil {
+		return models.Category{}, err
+	}
+
+	if err := cs.validate(category); err != nil {
+		return models.Category{}, err
+	}
+
+	cs.expectVersion(&category)
+
+	return cs.repo.Update(category)
+}
+
+// Delete moves a category to the trash. Its reminders, templates and
+// subcategories are handled according to the query's strategy first, all in
+// one transaction, and a category still in use is only deleted on reassign
+// or cascade. Reminders already in the trash stay with the category.
+func (cs *CategoryService) Delete(userID int64, id int64, query models.CategoryDeleteQuery) error {
 	log.Println("Deleting category")
 
-	err := cs.repo.Delete(id)
+	return cs.db.Transaction(func(tx *gorm.DB) error {
+		categoryService := NewCategoryService(tx)
+
+		if err := categoryService.checkVersion(userID, id, cs.ifVersion); err != nil {
+			return err
+		}
+
+		return categoryService.delete(userID, id, query)
+	})
+}
+
+func (cs *CategoryService) delete(userID int64, id int64, query models.CategoryDeleteQuery) error {
+	reminderService := NewReminderService(cs.db)
+	templateRepo := repository.NewReminderTemplateRepository(cs.db)
+
+	if _, err := cs.GetForUser(userID, id); err != nil {
+		return err
+	}
+
+	reminders, err := reminderService.repo.FindByCategoryID(id)
+
+	if err != nil {
+		return err
+	}
+
+	templates, err := templateRepo.FindByCategoryID(id)
+
+	if err != nil {
+		return err
+	}
+
+	children, err := cs.repo.FindChildren(id)
+
+	if err != nil {
+		return err
+	}
+
+	switch query.Strategy {
+	case models.CategoryDeleteReassign:
+		if err := cs.checkReassignTarget(userID, id, query.Target); err != nil {
+			return err
+		}
+
+		// Moving them one by one in order appends them to the target in
+		// the order they had
+		for _, reminder := range reminders {
+			move := models.ReminderMoveRequest{CategoryID: query.Target}
+
+			if _, err := reminderService.Move(userID, reminder.ID, move); err != nil {
+				return err
+			}
+		}
 
-	return err
+		for _, template := range templates {
+			template.CategoryID = *query.Target
+
+			if _, err := templateRepo.Update(template); err != nil {
+				return err
+			}
+		}
+
+		for _, child := range children {
+			if _, err := cs.Move(userID, child.ID, models.CategoryMoveRequest{ParentID: query.Target}); err != nil {
+				return err
+			}
+		}
+	case models.CategoryDeleteCascade:
+		for _, reminder := range reminders {
+			if err := reminderService.Delete(reminder.ID); err != nil {
+				return err
+			}
+		}
+
+		for _, template := range templates {
+			if err := templateRepo.Trash(template.ID); err != nil {
+				return err
+			}
+		}
+
+		for _, child := range children {
+			if err := cs.delete(userID, child.ID, query); err != nil {
+				return err
+			}
+		}
+	default:
+		if len(reminders) > 0 || len(templates) > 0 || len(children) > 0 {
+			return errors.New(utils.ErrorCategoryInUse)
+		}
+	}
+
+	return cs.repo.Delete(id)
 }
 
-func (cs *CategoryService) CreateBulk(categories []models.Category) error {
-	_, err := cs.repo.CreateBulk(categories)
+// Merge moves the reminders, templates, subcategories and saved filters of
+// the source categories into the target and deletes the sources, all in one
+// transaction. Reminders already in the trash stay with their category.
+func (cs *CategoryService) Merge(userID int64, id int64, request models.CategoryMergeRequest) (models.CategoryMergeResponse, error) {
+	log.Println("Merging categories")
+
+	response := models.CategoryMergeResponse{MergedIDs: []int64{}}
+
+	err := cs.db.Transaction(func(tx *gorm.DB) error {
+		categoryService := NewCategoryService(tx)
+
+		if err := categoryService.checkVersion(userID, id, cs.ifVersion); err != nil {
+			return err
+		}
+
+		for _, sourceID := range request.SourceIDs {
+			// Listed twice
+			if slices.Contains(response.MergedIDs, sourceID) {
+				continue
+			}
+
+			if err := categoryService.checkMergeSource(userID, id, sourceID); err != nil {
+				return err
+			}
+
+			if err := categoryService.merge(userID, id, sourceID, &response); err != nil {
+				return err
+			}
+		}
+
+		category, err := categoryService.Get(id)
+		response.Category = category
+
+		return err
+	})
+
+	if err != nil {
+		return models.CategoryMergeResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (cs *CategoryService) merge(userID int64, id int64, sourceID int64, response *models.CategoryMergeResponse) error {
+	reminderService := NewReminderService(cs.db)
+	templateRepo := repository.NewReminderTemplateRepository(cs.db)
+	savedFilterRepo := repository.NewSavedFilterRepository(cs.db)
+
+	reminders, err := reminderService.repo.FindByCategoryID(sourceID)
+
+	if err != nil {
+		return err
+	}
+
+	// Moving them one by one in order appends them to the target in the
+	// order they had
+	for _, reminder := range reminders {
+		if _, err := reminderService.Move(userID, reminder.ID, models.ReminderMoveRequest{CategoryID: &id}); err != nil {
+			return err
+		}
+
+		response.Reminders++
+	}
+
+	templates, err := templateRepo.FindByCategoryID(sourceID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, template := range templates {
+		template.CategoryID = id
+
+		if _, err := templateRepo.Update(template); err != nil {
+			return err
+		}
+
+		response.Templates++
+	}
+
+	savedFilters, err := savedFilterRepo.FindByUserID(userID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, savedFilter := range savedFilters {
+		if savedFilter.Filter.CategoryID == nil || *savedFilter.Filter.CategoryID != sourceID {
+			continue
+		}
+
+		savedFilter.Filter.CategoryID = &id
+
+		if _, err := savedFilterRepo.Update(savedFilter); err != nil {
+			return err
+		}
+
+		response.SavedFilters++
+	}
+
+	children, err := cs.repo.FindChildren(sourceID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if _, err := cs.Move(userID, child.ID, models.CategoryMoveRequest{ParentID: &id}); err != nil {
+			return err
+		}
+
+		response.Subcategories++
+	}
+
+	if err := cs.repo.Delete(sourceID); err != nil {
+		return err
+	}
+
+	response.MergedIDs = append(response.MergedIDs, sourceID)
+
+	return nil
+}
+
+// expectVersion holds the save of a loaded category to the version the
+// service was given, if any, in place of the version it was loaded with.
+func (cs *CategoryService) expectVersion(category *models.Category) {
+	if cs.ifVersion != nil {
+		category.Version = *cs.ifVersion
+	}
+}
+
+// checkVersion makes sure the user's category exists and, when a version is
+// given, still has it. Subcategories changed along the way aren't held to
+// it, so the transactions check it up front rather than on every save.
+func (cs *CategoryService) checkVersion(userID int64, id int64, version *int64) error {
+	category, err := cs.GetForUser(userID, id)
+
+	if err != nil {
+		return err
+	}
+
+	if version != nil && category.Version != *version {
+		return errors.New(utils.ErrorVersionConflict)
+	}
+
+	return nil
+}
+
+// checkMergeSource makes sure the source is another category of the user
+// that can be emptied into the target, which must not be inside it.
+func (cs *CategoryService) checkMergeSource(userID int64, id int64, sourceID int64) error {
+	categories, err := cs.List(userID)
+
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(categories, func(c models.Category) bool { return c.ID == sourceID }) {
+		return errors.New(utils.ErrorInvalidMergeSource)
+	}
+
+	if sourceID == id || slices.Contains(descendantIDs(categories, sourceID), id) {
+		return errors.New(utils.ErrorInvalidMergeSource)
+	}
+
+	return nil
+}
+
+// checkReassignTarget makes sure what a deleted category holds can move to
+// the target, which must not be the category or one of its subcategories.
+func (cs *CategoryService) checkReassignTarget(userID int64, id int64, target *int64) error {
+	if target == nil {
+		return errors.New(utils.ErrorInvalidReassignTarget)
+	}
+
+	categories, err := cs.List(userID)
+
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(categories, func(c models.Category) bool { return c.ID == *target }) {
+		return errors.New(utils.ErrorInvalidReassignTarget)
+	}
+
+	if *target == id || slices.Contains(descendantIDs(categories, id), *target) {
+		return errors.New(utils.ErrorInvalidReassignTarget)
+	}
+
+	return nil
+}
+
+// checkParent makes sure the category's parent is another category of its
+// owner outside its own subtree, and that the subtree still fits within the
+// maximum depth there.
+func (cs *CategoryService) checkParent(category models.Category) error {
+	if category.ParentID == nil {
+		return nil
+	}
+
+	categories, err := cs.List(category.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int64]models.Category, len(categories))
+
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	if _, ok := byID[*category.ParentID]; !ok {
+		return errors.New(utils.ErrorInvalidCategoryParent)
+	}
+
+	// A category that doesn't exist yet has no subtree to worry about
+	levels := 1
+
+	if category.ID != 0 {
+		if *category.ParentID == category.ID || slices.Contains(descendantIDs(categories, category.ID), *category.ParentID) {
+			return errors.New(utils.ErrorCategoryCycle)
+		}
+
+		levels = subtreeHeight(categories, category.ID)
+	}
+
+	if categoryDepth(byID, *category.ParentID)+levels > cs.maxDepth {
+		return errors.New(utils.ErrorCategoryTooDeep)
+	}
+
+	return nil
+}
+
+// validate checks a category before it is saved. Names are unique per user
+// regardless of case, and color, icon and the defaults may be left empty.
+// Default tags must belong to the category's owner.
+func (cs *CategoryService) validate(category models.Category) error {
+	if category.Name == "" {
+		return errors.New(utils.ErrorCategoryNameRequired)
+	}
+
+	if category.Color != "" && !utils.IsValidHexColor(category.Color) {
+		return errors.New(utils.ErrorInvalidCategoryColor)
+	}
+
+	if category.Icon != "" && !utils.IsValidCategoryIcon(category.Icon) {
+		return errors.New(utils.ErrorInvalidCategoryIcon)
+	}
+
+	if category.DefaultPriority != "" && !utils.IsValidPriority(category.DefaultPriority) {
+		return errors.New(utils.ErrorInvalidPriority)
+	}
+
+	if category.DefaultDueTime != "" {
+		if _, err := time.Parse(models.TimeOfDayLayout, category.DefaultDueTime); err != nil {
+			return errors.New(utils.ErrorInvalidDueTime)
+		}
+	}
+
+	if len(category.DefaultTagIDs) > 0 {
+		if _, err := NewReminderService(cs.db).findTags(category.UserID, category.DefaultTagIDs); err != nil {
+			return err
+		}
+	}
+
+	taken, err := cs.repo.NameTaken(category.UserID, category.Name, category.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if taken {
+		return errors.New(utils.ErrorCategoryNameTaken)
+	}
+
+	return nil
+}
+
+// childrenByParent groups categories by their parent's id, with top-level
+// ones under 0.
+func childrenByParent(categories []models.Category) map[int64][]models.Category {
+	children := make(map[int64][]models.Category)
+
+	for _, category := range categories {
+		var parentID int64
+
+		if category.ParentID != nil {
+			parentID = *category.ParentID
+		}
+
+		children[parentID] = append(children[parentID], category)
+	}
+
+	return children
+}
+
+// descendantIDs returns the ids of every category below the given one.
+func descendantIDs(categories []models.Category, id int64) []int64 {
+	children := childrenByParent(categories)
+
+	var ids []int64
+
+	for pending := []int64{id}; len(pending) > 0; pending = pending[1:] {
+		for _, child := range children[pending[0]] {
+			ids = append(ids, child.ID)
+			pending = append(pending, child.ID)
+		}
+	}
+
+	return ids
+}
+
+// categoryDepth counts the levels from the top down to the category, which
+// is 1 for a top-level category.
+func categoryDepth(byID map[int64]models.Category, id int64) int {
+	levels := 0
+
+	for current := &id; current != nil; levels++ {
+		category, ok := byID[*current]
+
+		if !ok {
+			break
+		}
+
+		current = category.ParentID
+	}
+
+	return levels
+}
+
+// subtreeHeight counts the levels of the category's subtree including
+// itself.
+func subtreeHeight(categories []models.Category, id int64) int {
+	children := childrenByParent(categories)
+
+	var measure func(id int64) int
+
+	measure = func(id int64) int {
+		tallest := 0
+
+		for _, child := range children[id] {
+			tallest = max(tallest, measure(child.ID))
+		}
+
+		return tallest + 1
+	}
 
-	return err
+	return measure(id)
 }
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"fmt"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type DuplicateService struct {
+	db              *gorm.DB
+	reminderService *ReminderService
+}
+
+func NewDuplicateService(db *gorm.DB) *DuplicateService {
+	return &DuplicateService{
+		db:              db,
+		reminderService: NewReminderService(db),
+	}
+}
+
+// DuplicateReminder copies a reminder with its checklist, tags and
+// attachments. The copy starts out pending with every item unchecked, and
+// shares the attachment blobs of the original.
+func (ds *DuplicateService) DuplicateReminder(userID int64, id int64, request models.ReminderDuplicateRequest) (models.Reminder, error) {
+	original, err := ds.reminderService.GetForUser(userID, id)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	categoryID := original.CategoryID
+
+	if request.CategoryID != nil {
+		if _, err := ds.getCategory(userID, *request.CategoryID); err != nil {
+			return models.Reminder{}, err
+		}
+
+		categoryID = *request.CategoryID
+	}
+
+	var reminder models.Reminder
+
+	err = ds.db.Transaction(func(tx *gorm.DB) error {
+		reminder, err = copyReminder(tx, userID, original, categoryID, request.ShiftDays)
+
+		return err
+	})
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	return ds.reminderService.Get(reminder.ID)
+}
+
+// DuplicateCategory copies a category together with its reminders, keeping
+// their manual order. The copy sits next to the original, and archived
+// reminders and subcategories are left behind.
+func (ds *DuplicateService) DuplicateCategory(userID int64, id int64, request models.CategoryDuplicateRequest) (models.Category, error) {
+	original, err := ds.getCategory(userID, id)
+
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	reminders, err := ds.reminderService.repo.FindByFilter(userID, models.ReminderFilter{CategoryID: &id})
+
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	// Copies are appended one by one, so this decides their order
+	slices.SortFunc(reminders, func(a models.Reminder, b models.Reminder) int {
+		if order := strings.Compare(a.Position, b.Position); order != 0 {
+			return order
+		}
+
+		return int(a.ID - b.ID)
+	})
+
+	name := request.Name
+
+	if name == "" {
+		if name, err = ds.copyName(userID, original.Name); err != nil {
+			return models.Category{}, err
+		}
+	}
+
+	var category models.Category
+
+	err = ds.db.Transaction(func(tx *gorm.DB) error {
+		category, err = NewCategoryService(tx).Create(userID, models.CategoryCreateRequest{
+			Name:     name,
+			Color:    original.Color,
+			Icon:     original.Icon,
+			ParentID: original.ParentID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, reminder := range reminders {
+			if _, err := copyReminder(tx, userID, reminder, category.ID, request.ShiftDays); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return category, err
+}
+
+func (ds *DuplicateService) getCategory(userID int64, id int64) (models.Category, error) {
+	return NewCategoryService(ds.db).GetForUser(userID, id)
+}
+
+// copyName finds a free name for a copy, counting up from "Name (copy)".
+func (ds *DuplicateService) copyName(userID int64, name string) (string, error) {
+	categoryRepo := repository.NewCategoryRepository(ds.db)
+
+	for i := 1; ; i++ {
+		candidate := name + " (copy)"
+
+		if i > 1 {
+			candidate = fmt.Sprintf("%s (copy %d)", name, i)
+		}
+
+		taken, err := categoryRepo.NameTaken(userID, candidate, 0)
+
+		if err != nil || !taken {
+			return candidate, err
+		}
+	}
+}
+
+// copyReminder creates the copy of a reminder and its items and attachments
+// inside the given transaction.
+func copyReminder(tx *gorm.DB, userID int64, original models.Reminder, categoryID int64, shiftDays int) (models.Reminder, error) {
+	tagIDs := make([]int64, len(original.Tags))
+
+	for i, tag := range original.Tags {
+		tagIDs[i] = tag.ID
+	}
+
+	dueDate := original.DueDate
+
+	if dueDate != nil {
+		shifted := dueDate.AddDate(0, 0, shiftDays)
+		dueDate = &shifted
+	}
+
+	startDate := original.StartDate
+
+	if startDate != nil {
+		shifted := startDate.AddDate(0, 0, shiftDays)
+		startDate = &shifted
+	}
+
+	reminder, err := NewReminderService(tx).Create(userID, models.ReminderCreateRequest{
+		Title:            original.Title,
+		Description:      original.Description,
+		CategoryID:       categoryID,
+		DueDate:          dueDate,
+		StartDate:        startDate,
+		EstimatedMinutes: original.EstimatedMinutes,
+		Priority:         original.Priority,
+		IsRecurring:      original.IsRecurring,
+		RecurringPattern: original.RecurringPattern,
+		AutoComplete:     original.AutoComplete,
+		Pinned:           original.Pinned,
+		Flagged:          original.Flagged,
+		TagIDs:           tagIDs,
+	})
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	itemRepo := repository.NewReminderItemRepository(tx)
+	items, err := itemRepo.FindByReminderID(original.ID)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	for _, item := range items {
+		copied := models.ReminderItem{
+			ReminderID: reminder.ID,
+			Text:       item.Text,
+			Position:   item.Position,
+		}
+
+		if _, err := itemRepo.Create(copied); err != nil {
+			return models.Reminder{}, err
+		}
+	}
+
+	attachmentRepo := repository.NewAttachmentRepository(tx)
+	attachments, err := attachmentRepo.FindByReminderID(original.ID)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	for _, attachment := range attachments {
+		copied := models.Attachment{
+			ReminderID:  reminder.ID,
+			UserID:      userID,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+			StorageKey:  attachment.StorageKey,
+		}
+
+		if _, err := attachmentRepo.Create(copied); err != nil {
+			return models.Reminder{}, err
+		}
+	}
+
+	return reminder, nil
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultIdempotencyTTLHours = 24
+
+type IdempotencyService struct {
+	repo repository.IdempotencyKeyRepository
+	ttl  time.Duration
+}
+
+func NewIdempotencyService(db *gorm.DB) *IdempotencyService {
+	ttlHours := utils.GetEnvInt64("IDEMPOTENCY_TTL_HOURS", defaultIdempotencyTTLHours)
+
+	return &IdempotencyService{
+		repo: repository.NewIdempotencyKeyRepository(db),
+		ttl:  time.Duration(ttlHours) * time.Hour,
+	}
+}
+
+// Begin claims the key for a request with the given fingerprint and reports
+// whether it did. When the key is already taken the stored record is returned
+// for replay, unless it belongs to a different request or the first request
+// hasn't finished yet.
+func (is *IdempotencyService) Begin(userID int64, key string, fingerprint string) (models.IdempotencyKey, bool, error) {
+	existing, err := is.repo.FindByKey(userID, key)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.IdempotencyKey{}, false, err
+	}
+
+	if err == nil {
+		if !is.expired(existing) {
+			return existing, false, checkIdempotencyKey(existing, fingerprint)
+		}
+
+		if err := is.repo.Delete(existing.ID); err != nil {
+			return models.IdempotencyKey{}, false, err
+		}
+	}
+
+	record, err := is.repo.Create(models.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Headers:     map[string]string{},
+	})
+
+	if err != nil {
+		// A concurrent request with the same key got there first
+		if existing, findErr := is.repo.FindByKey(userID, key); findErr == nil {
+			return existing, false, checkIdempotencyKey(existing, fingerprint)
+		}
+
+		return models.IdempotencyKey{}, false, err
+	}
+
+	return record, true, nil
+}
+
+// Complete stores the response for replay.
+func (is *IdempotencyService) Complete(record models.IdempotencyKey, statusCode int, contentType string, headers map[string]string, body []byte) error {
+	record.StatusCode = statusCode
+	record.ContentType = contentType
+	record.Headers = headers
+	record.Body = body
+
+	return is.repo.Complete(record)
+}
+
+// Release frees the key again so the request can be retried, used when it
+// failed in a way worth retrying or never finished.
+func (is *IdempotencyService) Release(record models.IdempotencyKey) error {
+	return is.repo.Delete(record.ID)
+}
+
+func (is *IdempotencyService) PurgeExpired() error {
+	count, err := is.repo.DeleteCreatedBefore(utils.GetCurrentTime().Add(-is.ttl))
+
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Printf("Purged %d expired idempotency keys", count)
+	}
+
+	return nil
+}
+
+func (is *IdempotencyService) expired(record models.IdempotencyKey) bool {
+	return record.CreatedAt != nil && record.CreatedAt.Before(utils.GetCurrentTime().Add(-is.ttl))
+}
+
+func checkIdempotencyKey(record models.IdempotencyKey, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return errors.New(utils.ErrorIdempotencyKeyReused)
+	}
+
+	if record.StatusCode == 0 {
+		return errors.New(utils.ErrorIdempotencyKeyInProgress)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/parser"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Longest hashtag that can become a tag, matching TagCreateRequest
+const maxQuickAddTagLength = 50
+
+type QuickAddService struct {
+	db           *gorm.DB
+	userService  *UserService
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+}
+
+func NewQuickAddService(db *gorm.DB) *QuickAddService {
+	return &QuickAddService{
+		db:           db,
+		userService:  NewUserService(db),
+		categoryRepo: repository.NewCategoryRepository(db),
+		tagRepo:      repository.NewTagRepository(db),
+	}
+}
+
+// Add parses a quick-add line in the user's time zone and creates the
+// reminder it describes. A dry run only returns what would be created.
+//
+// Hashtags matching a category name pick the category, the rest become tags,
+// created on the fly if they don't exist yet. Anything the text leaves out
+// falls back to the category's defaults, or the user's first category,
+// medium priority and the end of the day.
+func (qs *QuickAddService) Add(userID int64, request models.QuickAddRequest, dryRun bool) (models.QuickAddResponse, error) {
+	location := qs.userService.Location(userID)
+	now := utils.GetCurrentTime().In(location)
+	result, err := parser.Parse(request.Text, now)
+
+	if errors.Is(err, parser.ErrNoTitle) {
+		return models.QuickAddResponse{}, errors.New(utils.ErrorQuickAddNoTitle)
+	}
+
+	if err != nil {
+		return models.QuickAddResponse{}, err
+	}
+
+	parsed := models.QuickAddParse{
+		Title:    result.Title,
+		DueDate:  result.DueDate,
+		HasTime:  result.HasTime,
+		RRule:    result.RRule,
+		Priority: result.Priority,
+		Tags:     []string{},
+		Timezone: location.String(),
+		Matched:  append([]string{}, result.Matched...),
+	}
+
+	categories, err := qs.categoryRepo.FindByUserID(userID)
+
+	if err != nil {
+		return models.QuickAddResponse{}, err
+	}
+
+	tags, err := qs.resolveHashtags(userID, result.Hashtags, categories, &parsed)
+
+	if err != nil {
+		return models.QuickAddResponse{}, err
+	}
+
+	if parsed.Category == nil {
+		if len(categories) == 0 {
+			return models.QuickAddResponse{}, errors.New(utils.ErrorCategoryNotFound)
+		}
+
+		parsed.Category = &categories[0]
+		parsed.Defaulted = append(parsed.Defaulted, "category")
+	}
+
+	if parsed.Priority == "" {
+		parsed.Priority = parsed.Category.DefaultPriority
+
+		if parsed.Priority == "" {
+			parsed.Priority = models.PriorityMedium
+		}
+
+		parsed.Defaulted = append(parsed.Defaulted, "priority")
+	}
+
+	// The category's default due time stands in for a time the text didn't
+	// give, and without a date for the end of today
+	dueTime := parsed.Category.DefaultDueTime
+
+	switch {
+	case parsed.DueDate == nil && dueTime != "":
+		next, err := nextTimeOfDay(now, dueTime)
+
+		if err != nil {
+			return models.QuickAddResponse{}, err
+		}
+
+		parsed.DueDate = &next
+		parsed.Defaulted = append(parsed.Defaulted, "due_date")
+	case parsed.DueDate == nil:
+		endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 0, 0, location)
+		parsed.DueDate = &endOfDay
+		parsed.Defaulted = append(parsed.Defaulted, "due_date")
+	case !parsed.HasTime && dueTime != "":
+		dueDate, err := atTimeOfDay(*parsed.DueDate, dueTime)
+
+		if err != nil {
+			return models.QuickAddResponse{}, err
+		}
+
+		parsed.DueDate = &dueDate
+		parsed.Defaulted = append(parsed.Defaulted, "due_time")
+	}
+
+	if dryRun {
+		return models.QuickAddResponse{Parsed: parsed}, nil
+	}
+
+	var reminder models.Reminder
+
+	err = qs.db.Transaction(func(tx *gorm.DB) error {
+		tagRepo := repository.NewTagRepository(tx)
+		tagIDs := make([]int64, 0, len(tags)+len(parsed.NewTags))
+
+		for _, tag := range tags {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+
+		for _, name := range parsed.NewTags {
+			tag, err := tagRepo.Create(models.Tag{Name: name, UserID: userID})
+
+			if err != nil {
+				return err
+			}
+
+			tagIDs = append(tagIDs, tag.ID)
+		}
+
+		// Without hashtags the category's default tags apply
+		if len(tagIDs) == 0 {
+			tagIDs = nil
+		}
+
+		reminder, err = NewReminderService(tx).Create(userID, models.ReminderCreateRequest{
+			Title:            parsed.Title,
+			CategoryID:       parsed.Category.ID,
+			DueDate:          parsed.DueDate,
+			Priority:         parsed.Priority,
+			IsRecurring:      parsed.RRule != "",
+			RecurringPattern: parsed.RRule,
+			TagIDs:           tagIDs,
+		})
+
+		return err
+	})
+
+	if err != nil {
+		return models.QuickAddResponse{}, err
+	}
+
+	return models.QuickAddResponse{Reminder: &reminder, Parsed: parsed}, nil
+}
+
+// resolveHashtags sorts hashtags into the category and existing or new tags.
+// Only the first hashtag naming a category is used as one.
+func (qs *QuickAddService) resolveHashtags(userID int64, hashtags []string, categories []models.Category, parsed *models.QuickAddParse) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	seen := make(map[string]bool, len(hashtags))
+
+	for _, hashtag := range hashtags {
+		if seen[strings.ToLower(hashtag)] || len(hashtag) > maxQuickAddTagLength {
+			continue
+		}
+
+		seen[strings.ToLower(hashtag)] = true
+
+		if parsed.Category == nil {
+			if category := findCategoryByName(categories, hashtag); category != nil {
+				parsed.Category = category
+				continue
+			}
+		}
+
+		tag, err := qs.tagRepo.FindByName(userID, hashtag)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			parsed.NewTags = append(parsed.NewTags, hashtag)
+			parsed.Tags = append(parsed.Tags, hashtag)
+			continue
+		}
+
+		if err != nil {
+			return []models.Tag{}, err
+		}
+
+		tags = append(tags, tag)
+		parsed.Tags = append(parsed.Tags, tag.Name)
+	}
+
+	return tags, nil
+}
+
+func findCategoryByName(categories []models.Category, name string) *models.Category {
+	for i := range categories {
+		if strings.EqualFold(categories[i].Name, name) {
+			return &categories[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const maxBulkReminders = 500
+
+type ReminderBulkService struct {
+	db *gorm.DB
+}
+
+func NewReminderBulkService(db *gorm.DB) *ReminderBulkService {
+	return &ReminderBulkService{
+		db: db,
+	}
+}
+
+// Apply runs a bulk action in a single transaction. If any reminder fails,
+// nothing is changed and the results say which reminders caused it.
+func (bs *ReminderBulkService) Apply(userID int64, request models.ReminderBulkRequest) (models.ReminderBulkResponse, error) {
+	response := models.ReminderBulkResponse{Action: request.Action}
+
+	if err := validateBulkRequest(request); err != nil {
+		return response, err
+	}
+
+	var shift time.Duration
+
+	if request.Action == models.BulkActionShiftDue {
+		shift, _ = time.ParseDuration(request.ShiftBy)
+	}
+
+	err := bs.db.Transaction(func(tx *gorm.DB) error {
+		reminderService := NewReminderService(tx)
+
+		ids, err := bs.resolveIDs(reminderService, userID, request)
+
+		if err != nil {
+			return err
+		}
+
+		var tags []models.Tag
+
+		switch request.Action {
+		case models.BulkActionMove:
+			if _, err := NewCategoryService(tx).GetForUser(userID, *request.CategoryID); err != nil {
+				return err
+			}
+		case models.BulkActionAddTags, models.BulkActionRemoveTags:
+			if tags, err = reminderService.findTags(userID, request.TagIDs); err != nil {
+				return err
+			}
+		}
+
+		failed := false
+
+		for _, id := range ids {
+			result := models.ReminderBulkResult{ID: id, Success: true}
+
+			warnings, err := applyBulkAction(reminderService, userID, id, request, shift, tags)
+			result.Warnings = warnings
+
+			if err != nil {
+				result.Success = false
+				result.Error = err.Error()
+				failed = true
+			}
+
+			response.Results = append(response.Results, result)
+		}
+
+		if failed {
+			return errors.New(utils.ErrorBulkActionFailed)
+		}
+
+		return nil
+	})
+
+	return response, err
+}
+
+// resolveIDs returns the reminders targeted by a request, dropping duplicates
+// while keeping the order they were given in.
+func (bs *ReminderBulkService) resolveIDs(reminderService *ReminderService, userID int64, request models.ReminderBulkRequest) ([]int64, error) {
+	if request.Filter == nil {
+		ids := make([]int64, 0, len(request.IDs))
+		seen := make(map[int64]bool, len(request.IDs))
+
+		for _, id := range request.IDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		return ids, nil
+	}
+
+	if err := ValidateReminderFilter(*request.Filter); err != nil {
+		return []int64{}, err
+	}
+
+	reminders, err := reminderService.repo.FindByFilter(userID, resolveDueWindow(*request.Filter, utils.GetCurrentTime()))
+
+	if err != nil {
+		return []int64{}, err
+	}
+
+	if len(reminders) > maxBulkReminders {
+		return []int64{}, errors.New(utils.ErrorInvalidBulkRequest)
+	}
+
+	ids := make([]int64, len(reminders))
+
+	for i, reminder := range reminders {
+		ids[i] = reminder.ID
+	}
+
+	return ids, nil
+}
+
+// applyBulkAction applies the action to one reminder and returns the warnings
+// it raised, such as a WIP limit the reminder now exceeds.
+func applyBulkAction(rs *ReminderService, userID int64, id int64, request models.ReminderBulkRequest, shift time.Duration, tags []models.Tag) ([]string, error) {
+	reminder, err := rs.GetForUser(userID, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var updated models.Reminder
+
+	switch request.Action {
+	case models.BulkActionComplete:
+		_, err = rs.UpdateStatus(id, models.StatusCompleted)
+	case models.BulkActionReopen:
+		updated, err = rs.UpdateStatus(id, models.StatusPending)
+	case models.BulkActionDelete:
+		err = rs.Delete(id)
+	case models.BulkActionMove:
+		// Moving them one by one in order appends them to the category in
+		// the order they were given. Ones already there keep their place.
+		if reminder.CategoryID != *request.CategoryID {
+			updated, err = rs.Move(userID, id, models.ReminderMoveRequest{CategoryID: request.CategoryID})
+		}
+	case models.BulkActionSetPriority:
+		reminder.Priority = request.Priority
+		_, err = rs.repo.Update(reminder)
+	case models.BulkActionShiftDue:
+		if reminder.DueDate == nil {
+			return nil, errors.New(utils.ErrorReminderNoDueDate)
+		}
+
+		dueDate := reminder.DueDate.Add(shift)
+		reminder.DueDate = &dueDate
+		_, err = rs.repo.Update(reminder)
+	case models.BulkActionAddTags:
+		err = rs.repo.AddTags(reminder, tags)
+	case models.BulkActionRemoveTags:
+		err = rs.repo.RemoveTags(reminder, tags)
+	}
+
+	return updated.Warnings, err
+}
+
+// validateBulkRequest checks that the reminders are picked one way only and
+// that the fields the action needs are set.
+func validateBulkRequest(request models.ReminderBulkRequest) error {
+	if (len(request.IDs) == 0) == (request.Filter == nil) || len(request.IDs) > maxBulkReminders {
+		return errors.New(utils.ErrorInvalidBulkRequest)
+	}
+
+	switch request.Action {
+	case models.BulkActionMove:
+		if request.CategoryID == nil {
+			return errors.New(utils.ErrorInvalidBulkRequest)
+		}
+	case models.BulkActionSetPriority:
+		if !utils.IsValidPriority(request.Priority) {
+			return errors.New(utils.ErrorInvalidPriority)
+		}
+	case models.BulkActionShiftDue:
+		if shift, err := time.ParseDuration(request.ShiftBy); err != nil || shift == 0 {
+			return errors.New(utils.ErrorInvalidBulkRequest)
+		}
+	case models.BulkActionAddTags, models.BulkActionRemoveTags:
+		if len(request.TagIDs) == 0 {
+			return errors.New(utils.ErrorInvalidBulkRequest)
+		}
+	}
+
+	return nil
+}
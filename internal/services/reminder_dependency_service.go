@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type ReminderDependencyService struct {
+	repo            repository.ReminderDependencyRepository
+	reminderService *ReminderService
+}
+
+func NewReminderDependencyService(db *gorm.DB) *ReminderDependencyService {
+	return &ReminderDependencyService{
+		repo:            repository.NewReminderDependencyRepository(db),
+		reminderService: NewReminderService(db),
+	}
+}
+
+func (ds *ReminderDependencyService) List(userID int64, reminderID int64) (models.ReminderDependencies, error) {
+	if _, err := ds.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.ReminderDependencies{}, err
+	}
+
+	blockedBy, err := ds.repo.FindBlockers(reminderID)
+
+	if err != nil {
+		return models.ReminderDependencies{}, err
+	}
+
+	blocking, err := ds.repo.FindDependents(reminderID)
+
+	if err != nil {
+		return models.ReminderDependencies{}, err
+	}
+
+	return models.ReminderDependencies{
+		BlockedBy: blockedBy,
+		Blocking:  blocking,
+	}, nil
+}
+
+// Add makes a reminder wait for another one. Both must belong to the user and
+// the new dependency can't close a loop.
+func (ds *ReminderDependencyService) Add(userID int64, reminderID int64, request models.ReminderDependencyCreateRequest) (models.Reminder, error) {
+	if _, err := ds.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.Reminder{}, err
+	}
+
+	if _, err := ds.reminderService.GetForUser(userID, request.BlockedByID); err != nil {
+		return models.Reminder{}, err
+	}
+
+	exists, err := ds.repo.Exists(reminderID, request.BlockedByID)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	if exists {
+		return models.Reminder{}, errors.New(utils.ErrorDependencyExists)
+	}
+
+	cycle, err := ds.createsCycle(reminderID, request.BlockedByID)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	if cycle {
+		return models.Reminder{}, errors.New(utils.ErrorDependencyCycle)
+	}
+
+	dependency := models.ReminderDependency{
+		ReminderID:  reminderID,
+		BlockedByID: request.BlockedByID,
+	}
+
+	if _, err := ds.repo.Create(dependency); err != nil {
+		return models.Reminder{}, err
+	}
+
+	return ds.reminderService.Get(reminderID)
+}
+
+func (ds *ReminderDependencyService) Remove(userID int64, reminderID int64, blockedByID int64) error {
+	if _, err := ds.reminderService.GetForUser(userID, reminderID); err != nil {
+		return err
+	}
+
+	exists, err := ds.repo.Exists(reminderID, blockedByID)
+
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return errors.New(utils.ErrorDependencyNotFound)
+	}
+
+	return ds.repo.Delete(reminderID, blockedByID)
+}
+
+// createsCycle walks the blockers of blockedByID, level by level, looking for
+// reminderID. Finding it means the reminder would end up waiting on itself.
+func (ds *ReminderDependencyService) createsCycle(reminderID int64, blockedByID int64) (bool, error) {
+	visited := map[int64]bool{blockedByID: true}
+	frontier := []int64{blockedByID}
+
+	for len(frontier) > 0 {
+		if visited[reminderID] {
+			return true, nil
+		}
+
+		dependencies, err := ds.repo.FindBlockerIDs(frontier)
+
+		if err != nil {
+			return false, err
+		}
+
+		frontier = frontier[:0]
+
+		for _, dependency := range dependencies {
+			if !visited[dependency.BlockedByID] {
+				visited[dependency.BlockedByID] = true
+				frontier = append(frontier, dependency.BlockedByID)
+			}
+		}
+	}
+
+	return visited[reminderID], nil
+}
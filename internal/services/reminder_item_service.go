@@ -0,0 +1,238 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type ReminderItemService struct {
+	db              *gorm.DB
+	repo            repository.ReminderItemRepository
+	reminderService *ReminderService
+}
+
+func NewReminderItemService(db *gorm.DB) *ReminderItemService {
+	return &ReminderItemService{
+		db:              db,
+		repo:            repository.NewReminderItemRepository(db),
+		reminderService: NewReminderService(db),
+	}
+}
+
+func (is *ReminderItemService) List(userID int64, reminderID int64) ([]models.ReminderItem, error) {
+	if _, err := is.reminderService.GetForUser(userID, reminderID); err != nil {
+		return []models.ReminderItem{}, err
+	}
+
+	items, err := is.repo.FindByReminderID(reminderID)
+
+	if err != nil {
+		return []models.ReminderItem{}, err
+	}
+
+	return items, nil
+}
+
+// Create, Update and Delete change an item and complete an auto-completing
+// reminder in one transaction, so the reminder's state always matches its
+// items.
+func (is *ReminderItemService) Create(userID int64, reminderID int64, request models.ReminderItemCreateRequest) (models.ReminderItem, error) {
+	var item models.ReminderItem
+
+	err := is.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		item, err = NewReminderItemService(tx).create(userID, reminderID, request)
+
+		return err
+	})
+
+	return item, err
+}
+
+func (is *ReminderItemService) create(userID int64, reminderID int64, request models.ReminderItemCreateRequest) (models.ReminderItem, error) {
+	reminder, err := is.reminderService.GetForUser(userID, reminderID)
+
+	if err != nil {
+		return models.ReminderItem{}, err
+	}
+
+	items, err := is.repo.FindByReminderID(reminderID)
+
+	if err != nil {
+		return models.ReminderItem{}, err
+	}
+
+	// Append by default, otherwise make room at the requested position
+	position := len(items)
+
+	if len(items) > 0 {
+		position = items[len(items)-1].Position + 1
+	}
+
+	if request.Position != nil && *request.Position < position {
+		position = *request.Position
+
+		if err := is.repo.ShiftPositions(reminderID, position); err != nil {
+			return models.ReminderItem{}, err
+		}
+	}
+
+	item, err := is.repo.Create(models.ReminderItem{
+		ReminderID: reminderID,
+		Text:       request.Text,
+		Done:       request.Done,
+		Position:   position,
+	})
+
+	if err != nil {
+		return models.ReminderItem{}, err
+	}
+
+	return item, is.completeIfDone(reminder)
+}
+
+func (is *ReminderItemService) Update(userID int64, reminderID int64, itemID int64, request models.ReminderItemUpdateRequest) (models.ReminderItem, error) {
+	var item models.ReminderItem
+
+	err := is.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		item, err = NewReminderItemService(tx).update(userID, reminderID, itemID, request)
+
+		return err
+	})
+
+	return item, err
+}
+
+func (is *ReminderItemService) update(userID int64, reminderID int64, itemID int64, request models.ReminderItemUpdateRequest) (models.ReminderItem, error) {
+	reminder, err := is.reminderService.GetForUser(userID, reminderID)
+
+	if err != nil {
+		return models.ReminderItem{}, err
+	}
+
+	item, err := is.get(reminderID, itemID)
+
+	if err != nil {
+		return models.ReminderItem{}, err
+	}
+
+	if request.Text != nil {
+		item.Text = *request.Text
+	}
+
+	if request.Done != nil {
+		item.Done = *request.Done
+	}
+
+	updatedItem, err := is.repo.Update(item)
+
+	if err != nil {
+		return models.ReminderItem{}, err
+	}
+
+	return updatedItem, is.completeIfDone(reminder)
+}
+
+func (is *ReminderItemService) Delete(userID int64, reminderID int64, itemID int64) error {
+	return is.db.Transaction(func(tx *gorm.DB) error {
+		return NewReminderItemService(tx).delete(userID, reminderID, itemID)
+	})
+}
+
+func (is *ReminderItemService) delete(userID int64, reminderID int64, itemID int64) error {
+	reminder, err := is.reminderService.GetForUser(userID, reminderID)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := is.get(reminderID, itemID); err != nil {
+		return err
+	}
+
+	if err := is.repo.Delete(itemID); err != nil {
+		return err
+	}
+
+	return is.completeIfDone(reminder)
+}
+
+// Reorder stores a new order for the items of a reminder. The request must
+// list every item exactly once.
+func (is *ReminderItemService) Reorder(userID int64, reminderID int64, request models.ReminderItemReorderRequest) ([]models.ReminderItem, error) {
+	if _, err := is.reminderService.GetForUser(userID, reminderID); err != nil {
+		return []models.ReminderItem{}, err
+	}
+
+	items, err := is.repo.FindByReminderID(reminderID)
+
+	if err != nil {
+		return []models.ReminderItem{}, err
+	}
+
+	if len(request.ItemIDs) != len(items) {
+		return []models.ReminderItem{}, errors.New(utils.ErrorInvalidItemOrder)
+	}
+
+	remaining := make(map[int64]bool, len(items))
+
+	for _, item := range items {
+		remaining[item.ID] = true
+	}
+
+	for _, id := range request.ItemIDs {
+		if !remaining[id] {
+			return []models.ReminderItem{}, errors.New(utils.ErrorInvalidItemOrder)
+		}
+
+		delete(remaining, id)
+	}
+
+	if err := is.repo.UpdatePositions(request.ItemIDs); err != nil {
+		return []models.ReminderItem{}, err
+	}
+
+	return is.repo.FindByReminderID(reminderID)
+}
+
+func (is *ReminderItemService) get(reminderID int64, itemID int64) (models.ReminderItem, error) {
+	item, err := is.repo.FindByID(itemID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && item.ReminderID != reminderID) {
+		return models.ReminderItem{}, errors.New(utils.ErrorItemNotFound)
+	}
+
+	return item, err
+}
+
+// completeIfDone marks an auto-completing reminder as completed once every
+// one of its items is done.
+func (is *ReminderItemService) completeIfDone(reminder models.Reminder) error {
+	if !reminder.AutoComplete || reminder.Status != models.StatusPending {
+		return nil
+	}
+
+	progress, err := is.repo.CountProgress([]int64{reminder.ID})
+
+	if err != nil || len(progress) == 0 {
+		return err
+	}
+
+	if progress[0].Done < progress[0].Total {
+		return nil
+	}
+
+	_, err = is.reminderService.UpdateStatus(reminder.ID, models.StatusCompleted)
+
+	// A blocked reminder stays open until its blockers are done
+	if err != nil && err.Error() == utils.ErrorReminderBlocked {
+		return nil
+	}
+
+	return err
+}
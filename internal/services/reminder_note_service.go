@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type ReminderNoteService struct {
+	repo            repository.ReminderNoteRepository
+	reminderService *ReminderService
+}
+
+func NewReminderNoteService(db *gorm.DB) *ReminderNoteService {
+	return &ReminderNoteService{
+		repo:            repository.NewReminderNoteRepository(db),
+		reminderService: NewReminderService(db),
+	}
+}
+
+func (ns *ReminderNoteService) List(userID int64, reminderID int64) ([]models.ReminderNote, error) {
+	if _, err := ns.reminderService.GetForUser(userID, reminderID); err != nil {
+		return []models.ReminderNote{}, err
+	}
+
+	notes, err := ns.repo.FindByReminderID(reminderID)
+
+	if err != nil {
+		return []models.ReminderNote{}, err
+	}
+
+	return notes, nil
+}
+
+func (ns *ReminderNoteService) Create(userID int64, reminderID int64, request models.ReminderNoteCreateRequest) (models.ReminderNote, error) {
+	if _, err := ns.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.ReminderNote{}, err
+	}
+
+	newNote := models.ReminderNote{
+		ReminderID: reminderID,
+		AuthorID:   userID,
+		Body:       request.Body,
+	}
+
+	note, err := ns.repo.Create(newNote)
+
+	return note, err
+}
+
+func (ns *ReminderNoteService) Update(userID int64, reminderID int64, noteID int64, request models.ReminderNoteUpdateRequest) (models.ReminderNote, error) {
+	note, err := ns.getAuthored(userID, reminderID, noteID)
+
+	if err != nil {
+		return models.ReminderNote{}, err
+	}
+
+	if note.Body == request.Body {
+		return note, nil
+	}
+
+	previousBody := note.Body
+	note.Body = request.Body
+
+	updatedNote, err := ns.repo.Update(note, previousBody)
+
+	return updatedNote, err
+}
+
+func (ns *ReminderNoteService) Delete(userID int64, reminderID int64, noteID int64) error {
+	if _, err := ns.getAuthored(userID, reminderID, noteID); err != nil {
+		return err
+	}
+
+	return ns.repo.Delete(noteID)
+}
+
+// History returns a note together with its previous bodies, newest first.
+func (ns *ReminderNoteService) History(userID int64, reminderID int64, noteID int64) (models.ReminderNote, error) {
+	if _, err := ns.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.ReminderNote{}, err
+	}
+
+	note, err := ns.get(reminderID, noteID)
+
+	if err != nil {
+		return models.ReminderNote{}, err
+	}
+
+	revisions, err := ns.repo.FindRevisions(noteID)
+
+	if err != nil {
+		return models.ReminderNote{}, err
+	}
+
+	note.Revisions = revisions
+
+	return note, nil
+}
+
+func (ns *ReminderNoteService) get(reminderID int64, noteID int64) (models.ReminderNote, error) {
+	note, err := ns.repo.FindByID(noteID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && note.ReminderID != reminderID) {
+		return models.ReminderNote{}, errors.New(utils.ErrorNoteNotFound)
+	}
+
+	return note, err
+}
+
+// getAuthored returns a note only if the user may change it, that is if they
+// own the reminder and wrote the note.
+func (ns *ReminderNoteService) getAuthored(userID int64, reminderID int64, noteID int64) (models.ReminderNote, error) {
+	if _, err := ns.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.ReminderNote{}, err
+	}
+
+	note, err := ns.get(reminderID, noteID)
+
+	if err != nil {
+		return models.ReminderNote{}, err
+	}
+
+	if note.AuthorID != userID {
+		return models.ReminderNote{}, errors.New(utils.ErrorNoteNotAuthor)
+	}
+
+	return note, nil
+}
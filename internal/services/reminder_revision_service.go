@@ -0,0 +1,169 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultUndoWindowSeconds = 300
+
+type ReminderRevisionService struct {
+	db              *gorm.DB
+	repo            repository.ReminderRevisionRepository
+	trashRepo       repository.TrashRepository
+	reminderService *ReminderService
+	undoWindow      time.Duration
+}
+
+func NewReminderRevisionService(db *gorm.DB) *ReminderRevisionService {
+	undoWindowSeconds := utils.GetEnvInt64("UNDO_WINDOW_SECONDS", defaultUndoWindowSeconds)
+
+	return &ReminderRevisionService{
+		db:              db,
+		repo:            repository.NewReminderRevisionRepository(db),
+		trashRepo:       repository.NewTrashRepository(db),
+		reminderService: NewReminderService(db),
+		undoWindow:      time.Duration(undoWindowSeconds) * time.Second,
+	}
+}
+
+// History returns the revisions of a reminder, newest first.
+func (rs *ReminderRevisionService) History(userID int64, reminderID int64) ([]models.ReminderRevision, error) {
+	if _, err := rs.reminderService.GetForUser(userID, reminderID); err != nil {
+		return []models.ReminderRevision{}, err
+	}
+
+	revisions, err := rs.repo.FindByReminderID(reminderID)
+
+	if err != nil {
+		return []models.ReminderRevision{}, err
+	}
+
+	return revisions, nil
+}
+
+// Revert puts the reminder back into the state it had right after the given
+// revision. The revert is itself recorded as a new revision.
+func (rs *ReminderRevisionService) Revert(userID int64, reminderID int64, revisionID int64) (models.Reminder, error) {
+	reminder, err := rs.reminderService.GetForUser(userID, reminderID)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	revision, err := rs.repo.FindByID(revisionID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && revision.ReminderID != reminderID) {
+		return models.Reminder{}, errors.New(utils.ErrorRevisionNotFound)
+	}
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	if revision.After == nil {
+		return models.Reminder{}, errors.New(utils.ErrorRevisionNotRevertible)
+	}
+
+	applySnapshot(&reminder, *revision.After)
+
+	if _, err := rs.reminderService.repo.UpdateWithTags(reminder, revision.After.TagIDs, models.RevisionActionRevert); err != nil {
+		return models.Reminder{}, err
+	}
+
+	return rs.reminderService.Get(reminderID)
+}
+
+// Undo reverses the latest change to a reminder if it was made within the
+// undo window. Undoing a creation or a restore moves the reminder to the
+// trash, and undoing a deletion brings it back.
+func (rs *ReminderRevisionService) Undo(userID int64, reminderID int64) (models.ReminderUndoResult, error) {
+	reminder, err := rs.reminderService.GetForUser(userID, reminderID)
+	deleted := false
+
+	if err != nil && err.Error() == utils.ErrorReminderNotFound {
+		// The last action may have been deleting the reminder
+		reminder, err = rs.trashRepo.FindReminder(userID, reminderID)
+		deleted = true
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ReminderUndoResult{}, errors.New(utils.ErrorReminderNotFound)
+		}
+	}
+
+	if err != nil {
+		return models.ReminderUndoResult{}, err
+	}
+
+	revision, err := rs.repo.FindLatest(reminderID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ReminderUndoResult{}, errors.New(utils.ErrorNothingToUndo)
+	}
+
+	if err != nil {
+		return models.ReminderUndoResult{}, err
+	}
+
+	if revision.CreatedAt == nil || utils.GetCurrentTime().Sub(*revision.CreatedAt) > rs.undoWindow {
+		return models.ReminderUndoResult{}, errors.New(utils.ErrorNothingToUndo)
+	}
+
+	result := models.ReminderUndoResult{Undone: revision}
+
+	err = rs.db.Transaction(func(tx *gorm.DB) error {
+		reminderRepo := repository.NewReminderRepository(tx)
+
+		switch {
+		case deleted && revision.Action == models.RevisionActionDelete:
+			trashRepo := repository.NewTrashRepository(tx)
+			return trashRepo.RestoreReminder(reminderID)
+		case deleted:
+			return errors.New(utils.ErrorNothingToUndo)
+		case revision.Before == nil:
+			return reminderRepo.Delete(reminderID)
+		default:
+			applySnapshot(&reminder, *revision.Before)
+			_, err := reminderRepo.UpdateWithTags(reminder, revision.Before.TagIDs, models.RevisionActionUndo)
+			return err
+		}
+	})
+
+	if err != nil {
+		return models.ReminderUndoResult{}, err
+	}
+
+	if current, err := rs.reminderService.Get(reminderID); err == nil {
+		result.Reminder = &current
+	}
+
+	return result, nil
+}
+
+func applySnapshot(reminder *models.Reminder, snapshot models.ReminderSnapshot) {
+	reminder.Title = snapshot.Title
+	reminder.Description = snapshot.Description
+	reminder.CategoryID = snapshot.CategoryID
+	reminder.DueDate = snapshot.DueDate
+	reminder.StartDate = snapshot.StartDate
+	reminder.EstimatedMinutes = snapshot.EstimatedMinutes
+	reminder.Priority = snapshot.Priority
+	reminder.Status = snapshot.Status
+	reminder.IsRecurring = snapshot.IsRecurring
+	reminder.RecurringPattern = snapshot.RecurringPattern
+	reminder.AutoComplete = snapshot.AutoComplete
+	reminder.Pinned = snapshot.Pinned
+	reminder.Flagged = snapshot.Flagged
+	reminder.CompletedAt = snapshot.CompletedAt
+	reminder.ArchivedAt = snapshot.ArchivedAt
+
+	// Revisions from before manual ordering have no position
+	if snapshot.Position != "" {
+		reminder.Position = snapshot.Position
+	}
+}
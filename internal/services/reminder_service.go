@@ -2,20 +2,35 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"log"
 	"reminder-server/internal/models"
+	"reminder-server/internal/ordering"
 	"reminder-server/internal/repository"
 	"reminder-server/internal/utils"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+const defaultAutoArchiveDays = 30
+
 type ReminderService struct {
-	repo repository.ReminderRepository
+	repo      repository.ReminderRepository
+	itemRepo  repository.ReminderItemRepository
+	tagRepo   repository.TagRepository
+	noteRepo  repository.ReminderNoteRepository
+	depRepo   repository.ReminderDependencyRepository
+	ifVersion *int64
 }
 
 func NewReminderService(db *gorm.DB) *ReminderService {
 	return &ReminderService{
-		repo: repository.NewReminderRepository(db),
+		repo:     repository.NewReminderRepository(db),
+		itemRepo: repository.NewReminderItemRepository(db),
+		tagRepo:  repository.NewTagRepository(db),
+		noteRepo: repository.NewReminderNoteRepository(db),
+		depRepo:  repository.NewReminderDependencyRepository(db),
 	}
 }
 
@@ -29,24 +44,43 @@ func (rs *ReminderService) ListAll() ([]models.Reminder, error) {
 	return reminders, nil
 }
 
-func (rs *ReminderService) List(userID int32) ([]models.Reminder, error) {
-	reminders, err := rs.repo.FindByUserID(int64(userID))
+// IfVersion returns a copy of the service that only saves or deletes a
+// reminder while its stored version still is the given one. The version is
+// compared in the transaction making the change, so a change landing after
+// the request's If-Match was checked isn't overwritten.
+func (rs *ReminderService) IfVersion(version int64) *ReminderService {
+	versioned := *rs
+	versioned.ifVersion = &version
+
+	return &versioned
+}
+
+func (rs *ReminderService) List(userID int32, filter models.ReminderFilter) ([]models.Reminder, error) {
+	if err := ValidateReminderFilter(filter); err != nil {
+		return []models.Reminder{}, err
+	}
+
+	reminders, err := rs.repo.FindByFilter(int64(userID), resolveDueWindow(filter, utils.GetCurrentTime()))
 
 	if err != nil {
 		return []models.Reminder{}, err
 	}
 
-	tx := rs.repo.GetDB().Begin()
+	// Overdue isn't stored, so listing doesn't write anything and an idle
+	// list keeps its ETag
+	now := utils.GetCurrentTime()
 
-	// Check if the reminders are overdue
 	for i, reminder := range reminders {
-		if !reminder.IsOverdue && reminder.DueDate.Before(utils.GetCurrentTime()) {
-			reminders[i].IsOverdue = true
-			tx.Save(&reminders[i])
-		}
+		reminders[i].IsOverdue = reminder.DueDate != nil && reminder.DueDate.Before(now)
+	}
+
+	if err := rs.attachProgress(reminders); err != nil {
+		return []models.Reminder{}, err
 	}
 
-	tx.Commit()
+	if err := rs.attachBlocked(reminders); err != nil {
+		return []models.Reminder{}, err
+	}
 
 	return reminders, nil
 }
@@ -58,44 +92,262 @@ func (rs *ReminderService) Get(id int64) (models.Reminder, error) {
 		return models.Reminder{}, err
 	}
 
-	return reminder, nil
+	reminders := []models.Reminder{reminder}
+
+	if err := rs.attachProgress(reminders); err != nil {
+		return models.Reminder{}, err
+	}
+
+	if err := rs.attachBlocked(reminders); err != nil {
+		return models.Reminder{}, err
+	}
+
+	return reminders[0], nil
 }
 
-func (rs *ReminderService) Create(request models.ReminderCreateRequest) (models.Reminder, error) {
-	// TODO: Make it use a real user ID
+// GetForUser returns a reminder only if it belongs to the given user.
+func (rs *ReminderService) GetForUser(userID int64, id int64) (models.Reminder, error) {
+	reminder, err := rs.Get(id)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && reminder.UserID != userID) {
+		return models.Reminder{}, errors.New(utils.ErrorReminderNotFound)
+	}
+
+	return reminder, err
+}
+
+// LoadIncludes loads the optional related data named in a ?include= list.
+func (rs *ReminderService) LoadIncludes(reminder *models.Reminder, includes []string) error {
+	for _, include := range includes {
+		switch include {
+		case models.IncludeNotes:
+			notes, err := rs.noteRepo.FindByReminderID(reminder.ID)
+
+			if err != nil {
+				return err
+			}
+
+			reminder.Notes = notes
+		default:
+			return errors.New(utils.ErrorInvalidInclude)
+		}
+	}
+
+	return nil
+}
+
+// attachProgress fills in the checklist progress of reminders that have items.
+func (rs *ReminderService) attachProgress(reminders []models.Reminder) error {
+	ids := make([]int64, len(reminders))
+
+	for i, reminder := range reminders {
+		ids[i] = reminder.ID
+	}
+
+	progress, err := rs.itemRepo.CountProgress(ids)
+
+	if err != nil {
+		return err
+	}
+
+	byReminder := make(map[int64]models.ReminderProgress, len(progress))
+
+	for _, p := range progress {
+		byReminder[p.ReminderID] = p
+	}
+
+	for i := range reminders {
+		if p, ok := byReminder[reminders[i].ID]; ok {
+			reminders[i].Progress = &p
+		}
+	}
+
+	return nil
+}
+
+// attachBlocked marks reminders that still have pending blockers.
+func (rs *ReminderService) attachBlocked(reminders []models.Reminder) error {
+	ids := make([]int64, len(reminders))
+
+	for i, reminder := range reminders {
+		ids[i] = reminder.ID
+	}
+
+	dependencies, err := rs.depRepo.FindPendingBlockers(ids)
+
+	if err != nil {
+		return err
+	}
+
+	blocked := make(map[int64]bool, len(dependencies))
+
+	for _, dependency := range dependencies {
+		blocked[dependency.ReminderID] = true
+	}
+
+	for i := range reminders {
+		reminders[i].Blocked = blocked[reminders[i].ID]
+	}
+
+	return nil
+}
+
+func (rs *ReminderService) Create(userID int64, request models.ReminderCreateRequest) (models.Reminder, error) {
+	// Reminders can only go into the user's own categories
+	category, err := NewCategoryService(rs.repo.GetDB()).GetForUser(userID, request.CategoryID)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	if err := rs.applyCategoryDefaults(userID, category, &request); err != nil {
+		return models.Reminder{}, err
+	}
+
 	newReminder := models.Reminder{
 		Title:            request.Title,
 		Description:      request.Description,
-		DueDate:          &request.DueDate,
+		DueDate:          request.DueDate,
+		StartDate:        request.StartDate,
+		EstimatedMinutes: request.EstimatedMinutes,
 		CategoryID:       request.CategoryID,
 		IsRecurring:      request.IsRecurring,
 		RecurringPattern: request.RecurringPattern,
+		AutoComplete:     request.AutoComplete,
+		Pinned:           request.Pinned,
+		Flagged:          request.Flagged,
 		Priority:         request.Priority,
 		Status:           models.StatusPending,
-		UserID:           1,
+		UserID:           userID,
+	}
+
+	if !utils.IsValidPriority(request.Priority) {
+		return models.Reminder{}, errors.New(utils.ErrorInvalidPriority)
+	}
+
+	if startsAfterDue(newReminder) {
+		return models.Reminder{}, errors.New(utils.ErrorStartAfterDue)
 	}
 
-	// Check if the category exists
-	category, err := NewCategoryService(rs.repo.GetDB()).Get(request.CategoryID)
+	tags, err := rs.findTags(userID, request.TagIDs)
 
 	if err != nil {
 		return models.Reminder{}, err
 	}
 
-	if category.ID == 0 {
-		return models.Reminder{}, errors.New(utils.ErrorCategoryNotFound)
+	if newReminder.Position, err = rs.endPosition(request.CategoryID, 0); err != nil {
+		return models.Reminder{}, err
 	}
 
-	if !utils.IsValidPriority(request.Priority) {
-		return models.Reminder{}, errors.New(utils.ErrorInvalidPriority)
-	}
+	// The tags are attached in the transaction creating the reminder
+	newReminder.Tags = tags
 
 	reminder, err := rs.repo.Create(newReminder)
 
-	return reminder, err
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	if reminder.Tags == nil {
+		reminder.Tags = []models.Tag{}
+	}
+
+	if err := rs.checkWIPLimit(&reminder); err != nil {
+		return models.Reminder{}, err
+	}
+
+	return reminder, nil
+}
+
+// applyCategoryDefaults fills in the priority, due date and tags a create
+// request left out from the category's defaults. A missing due date becomes
+// the next time the category's default due time comes around. Default tags
+// that were deleted since are skipped.
+func (rs *ReminderService) applyCategoryDefaults(userID int64, category models.Category, request *models.ReminderCreateRequest) error {
+	if request.Priority == "" {
+		request.Priority = category.DefaultPriority
+	}
+
+	if request.Priority == "" {
+		request.Priority = models.PriorityMedium
+	}
+
+	if request.DueDate == nil && category.DefaultDueTime != "" {
+		now := utils.GetCurrentTime().In(NewUserService(rs.repo.GetDB()).Location(userID))
+		dueDate, err := nextTimeOfDay(now, category.DefaultDueTime)
+
+		if err != nil {
+			return err
+		}
+
+		request.DueDate = &dueDate
+	}
+
+	if request.DueDate == nil {
+		return errors.New(utils.ErrorDueDateRequired)
+	}
+
+	if request.TagIDs == nil && len(category.DefaultTagIDs) > 0 {
+		tags, err := rs.tagRepo.FindByIDs(userID, category.DefaultTagIDs)
+
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			request.TagIDs = append(request.TagIDs, tag.ID)
+		}
+	}
+
+	return nil
+}
+
+// checkWIPLimit adds a warning to a pending reminder whose category now holds
+// more pending reminders than its WIP limit allows. The reminder is saved
+// either way.
+func (rs *ReminderService) checkWIPLimit(reminder *models.Reminder) error {
+	if reminder.Status != models.StatusPending {
+		return nil
+	}
+
+	category, err := NewCategoryService(rs.repo.GetDB()).Get(reminder.CategoryID)
+
+	if err != nil || category.WIPLimit == 0 {
+		return err
+	}
+
+	pending, err := rs.repo.CountPending(category.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if pending > int64(category.WIPLimit) {
+		reminder.Warnings = append(reminder.Warnings, fmt.Sprintf(utils.WarningWIPLimitExceeded, category.Name, pending, category.WIPLimit))
+	}
+
+	return nil
 }
 
+// Update changes a reminder's fields and tags in one transaction, so a
+// failing tag change doesn't leave the field edits behind.
 func (rs *ReminderService) Update(id int64, request models.ReminderUpdateRequest) (models.Reminder, error) {
+	var reminder models.Reminder
+
+	err := rs.repo.GetDB().Transaction(func(tx *gorm.DB) error {
+		reminderService := NewReminderService(tx)
+		reminderService.ifVersion = rs.ifVersion
+
+		var err error
+		reminder, err = reminderService.update(id, request)
+
+		return err
+	})
+
+	return reminder, err
+}
+
+func (rs *ReminderService) update(id int64, request models.ReminderUpdateRequest) (models.Reminder, error) {
 	// Get the reminder
 	reminder, err := rs.Get(id)
 
@@ -111,20 +363,51 @@ func (rs *ReminderService) Update(id int64, request models.ReminderUpdateRequest
 		reminder.Description = *request.Description
 	}
 
-	if request.CategoryID != nil {
+	// Reminders landing in a category as pending count toward its WIP limit
+	checkLimit := false
+
+	if request.CategoryID != nil && *request.CategoryID != reminder.CategoryID {
+		checkLimit = true
+
+		if _, err := NewCategoryService(rs.repo.GetDB()).GetForUser(reminder.UserID, *request.CategoryID); err != nil {
+			return models.Reminder{}, err
+		}
+
 		reminder.CategoryID = *request.CategoryID
+
+		if reminder.Position, err = rs.endPosition(reminder.CategoryID, reminder.ID); err != nil {
+			return models.Reminder{}, err
+		}
 	}
 
 	if request.DueDate != nil {
 		reminder.DueDate = request.DueDate
 	}
 
+	if request.StartDate != nil {
+		reminder.StartDate = request.StartDate
+	}
+
+	if request.EstimatedMinutes != nil {
+		reminder.EstimatedMinutes = request.EstimatedMinutes
+	}
+
+	if startsAfterDue(reminder) {
+		return models.Reminder{}, errors.New(utils.ErrorStartAfterDue)
+	}
+
 	if request.Priority != nil {
 		reminder.Priority = *request.Priority
 	}
 
 	if request.Status != nil {
-		reminder.Status = *request.Status
+		if *request.Status == models.StatusCompleted && reminder.Status != models.StatusCompleted && reminder.Blocked {
+			return models.Reminder{}, errors.New(utils.ErrorReminderBlocked)
+		}
+
+		checkLimit = checkLimit || (*request.Status == models.StatusPending && reminder.Status != models.StatusPending)
+
+		setStatus(&reminder, *request.Status)
 	}
 
 	if request.IsRecurring != nil {
@@ -135,18 +418,130 @@ func (rs *ReminderService) Update(id int64, request models.ReminderUpdateRequest
 		reminder.RecurringPattern = *request.RecurringPattern
 	}
 
+	if request.AutoComplete != nil {
+		reminder.AutoComplete = *request.AutoComplete
+	}
+
+	if request.Pinned != nil {
+		reminder.Pinned = *request.Pinned
+	}
+
+	if request.Flagged != nil {
+		reminder.Flagged = *request.Flagged
+	}
+
+	rs.expectVersion(&reminder)
+
 	updatedReminder, err := rs.repo.Update(reminder)
 
-	return updatedReminder, err
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	if request.TagIDs != nil || len(request.AddTagIDs) > 0 || len(request.RemoveTagIDs) > 0 {
+		if err := rs.updateTags(updatedReminder, request); err != nil {
+			return models.Reminder{}, err
+		}
+
+		if updatedReminder, err = rs.Get(id); err != nil {
+			return models.Reminder{}, err
+		}
+	}
+
+	if checkLimit {
+		if err := rs.checkWIPLimit(&updatedReminder); err != nil {
+			return models.Reminder{}, err
+		}
+	}
+
+	return updatedReminder, nil
+}
+
+// updateTags applies the tag changes of an update request to a reminder.
+func (rs *ReminderService) updateTags(reminder models.Reminder, request models.ReminderUpdateRequest) error {
+	if request.TagIDs != nil {
+		tags, err := rs.findTags(reminder.UserID, *request.TagIDs)
+
+		if err != nil {
+			return err
+		}
+
+		if err := rs.repo.ReplaceTags(reminder, tags); err != nil {
+			return err
+		}
+	}
+
+	added, err := rs.findTags(reminder.UserID, request.AddTagIDs)
+
+	if err != nil {
+		return err
+	}
+
+	if err := rs.repo.AddTags(reminder, added); err != nil {
+		return err
+	}
+
+	removed, err := rs.findTags(reminder.UserID, request.RemoveTagIDs)
+
+	if err != nil {
+		return err
+	}
+
+	return rs.repo.RemoveTags(reminder, removed)
+}
+
+// findTags loads the given tags, failing if any of them isn't owned by the user.
+func (rs *ReminderService) findTags(userID int64, ids []int64) ([]models.Tag, error) {
+	tags, err := rs.tagRepo.FindByIDs(userID, ids)
+
+	if err != nil {
+		return []models.Tag{}, err
+	}
+
+	found := make(map[int64]bool, len(tags))
+
+	for _, tag := range tags {
+		found[tag.ID] = true
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			return []models.Tag{}, errors.New(utils.ErrorTagNotFound)
+		}
+	}
+
+	return tags, nil
 }
 
 func (rs *ReminderService) Delete(id int64) error {
-	err := rs.repo.Delete(id)
+	var err error
+
+	if rs.ifVersion != nil {
+		err = rs.repo.DeleteIfVersion(id, *rs.ifVersion)
+	} else {
+		err = rs.repo.Delete(id)
+	}
+
+	// Already in the trash or never there
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New(utils.ErrorReminderNotFound)
+	}
 
 	return err
 }
 
+// UpdateStatus changes the status of a reminder. Completing a reminder that
+// is blocked by pending reminders is rejected.
 func (rs *ReminderService) UpdateStatus(id int64, status string) (models.Reminder, error) {
+	return rs.updateStatus(id, status, false)
+}
+
+// ForceUpdateStatus changes the status of a reminder even if it is blocked.
+func (rs *ReminderService) ForceUpdateStatus(id int64, status string) (models.Reminder, error) {
+	return rs.updateStatus(id, status, true)
+}
+
+func (rs *ReminderService) updateStatus(id int64, status string, force bool) (models.Reminder, error) {
 	reminder, err := rs.Get(id)
 
 	if err != nil {
@@ -161,9 +556,338 @@ func (rs *ReminderService) UpdateStatus(id int64, status string) (models.Reminde
 		return reminder, nil
 	}
 
-	reminder.Status = status
+	if status == models.StatusCompleted && reminder.Blocked && !force {
+		return models.Reminder{}, errors.New(utils.ErrorReminderBlocked)
+	}
+
+	setStatus(&reminder, status)
+	rs.expectVersion(&reminder)
 
 	updatedReminder, err := rs.repo.Update(reminder)
 
-	return updatedReminder, err
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	// A reopened reminder counts toward its category's WIP limit again
+	if err := rs.checkWIPLimit(&updatedReminder); err != nil {
+		return models.Reminder{}, err
+	}
+
+	return updatedReminder, nil
+}
+
+// startsAfterDue reports whether a reminder's start date is after its due
+// date. It compares calendar days, as both dates are stored without a time.
+func startsAfterDue(reminder models.Reminder) bool {
+	if reminder.StartDate == nil || reminder.DueDate == nil {
+		return false
+	}
+
+	const day = "2006-01-02"
+
+	return reminder.StartDate.Format(day) > reminder.DueDate.Format(day)
+}
+
+// setStatus changes the status of a reminder and keeps its completion time in
+// sync. Reopening a reminder also takes it out of the archive.
+func setStatus(reminder *models.Reminder, status string) {
+	if reminder.Status == status {
+		return
+	}
+
+	reminder.Status = status
+
+	if status == models.StatusCompleted {
+		now := utils.GetCurrentTime()
+		reminder.CompletedAt = &now
+		return
+	}
+
+	reminder.CompletedAt = nil
+	reminder.ArchivedAt = nil
+}
+
+func (rs *ReminderService) Archive(userID int64, id int64) (models.Reminder, error) {
+	reminder, err := rs.GetForUser(userID, id)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	if reminder.ArchivedAt != nil {
+		return reminder, nil
+	}
+
+	now := utils.GetCurrentTime()
+	reminder.ArchivedAt = &now
+
+	return rs.repo.Update(reminder)
+}
+
+func (rs *ReminderService) Unarchive(userID int64, id int64) (models.Reminder, error) {
+	reminder, err := rs.GetForUser(userID, id)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	if reminder.ArchivedAt == nil {
+		return reminder, nil
+	}
+
+	reminder.ArchivedAt = nil
+
+	return rs.repo.Update(reminder)
+}
+
+// Move places a reminder before or after another reminder, which also moves
+// it into that reminder's category, or at the end of a category. Only the
+// moved reminder gets a new position.
+func (rs *ReminderService) Move(userID int64, id int64, request models.ReminderMoveRequest) (models.Reminder, error) {
+	reminder, err := rs.GetForUser(userID, id)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	categoryID, position, err := rs.movePosition(userID, reminder, request)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	// Inserting into the same gap again and again makes keys longer, so
+	// the category is spread out once they get too long and the move redone
+	if len(position) > ordering.MaxKeyLength {
+		if err := rs.repo.SpreadPositions(categoryID); err != nil {
+			return models.Reminder{}, err
+		}
+
+		if reminder, err = rs.GetForUser(userID, id); err != nil {
+			return models.Reminder{}, err
+		}
+
+		if categoryID, position, err = rs.movePosition(userID, reminder, request); err != nil {
+			return models.Reminder{}, err
+		}
+	}
+
+	checkLimit := categoryID != reminder.CategoryID
+
+	reminder.CategoryID = categoryID
+	reminder.Position = position
+
+	if _, err := rs.repo.UpdateAs(reminder, models.RevisionActionMove); err != nil {
+		return models.Reminder{}, err
+	}
+
+	if reminder, err = rs.Get(id); err != nil {
+		return models.Reminder{}, err
+	}
+
+	if checkLimit {
+		if err := rs.checkWIPLimit(&reminder); err != nil {
+			return models.Reminder{}, err
+		}
+	}
+
+	return reminder, nil
+}
+
+// movePosition works out the category and position a move request leads to.
+func (rs *ReminderService) movePosition(userID int64, reminder models.Reminder, request models.ReminderMoveRequest) (int64, string, error) {
+	if request.BeforeID != nil && request.AfterID != nil {
+		return 0, "", errors.New(utils.ErrorInvalidMove)
+	}
+
+	anchorID := request.BeforeID
+
+	if anchorID == nil {
+		anchorID = request.AfterID
+	}
+
+	if anchorID == nil {
+		if request.CategoryID == nil {
+			return 0, "", errors.New(utils.ErrorInvalidMove)
+		}
+
+		if _, err := NewCategoryService(rs.repo.GetDB()).GetForUser(userID, *request.CategoryID); err != nil {
+			return 0, "", err
+		}
+
+		position, err := rs.endPosition(*request.CategoryID, reminder.ID)
+
+		return *request.CategoryID, position, err
+	}
+
+	if *anchorID == reminder.ID {
+		return 0, "", errors.New(utils.ErrorInvalidMove)
+	}
+
+	anchor, err := rs.GetForUser(userID, *anchorID)
+
+	if err != nil {
+		return 0, "", err
+	}
+
+	if request.CategoryID != nil && *request.CategoryID != anchor.CategoryID {
+		return 0, "", errors.New(utils.ErrorInvalidMove)
+	}
+
+	adjacent, err := rs.repo.AdjacentPosition(anchor.CategoryID, anchor.Position, reminder.ID, request.AfterID != nil)
+
+	if err != nil {
+		return 0, "", err
+	}
+
+	var position string
+
+	if request.AfterID != nil {
+		position, err = ordering.Between(anchor.Position, adjacent)
+	} else {
+		position, err = ordering.Between(adjacent, anchor.Position)
+	}
+
+	return anchor.CategoryID, position, err
+}
+
+// expectVersion holds the save of a loaded reminder to the version the
+// service was given, if any, in place of the version it was loaded with.
+func (rs *ReminderService) expectVersion(reminder *models.Reminder) {
+	if rs.ifVersion != nil {
+		reminder.Version = *rs.ifVersion
+	}
+}
+
+// nextTimeOfDay returns the next moment after now at the given time of day
+// in now's time zone, which is today or tomorrow.
+func nextTimeOfDay(now time.Time, timeOfDay string) (time.Time, error) {
+	next, err := atTimeOfDay(now, timeOfDay)
+
+	if err == nil && !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next, err
+}
+
+// atTimeOfDay returns the given day at a time of day like 09:00.
+func atTimeOfDay(day time.Time, timeOfDay string) (time.Time, error) {
+	clock, err := time.Parse(models.TimeOfDayLayout, timeOfDay)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location()), nil
+}
+
+// endPosition returns a position after every other reminder in the category.
+func (rs *ReminderService) endPosition(categoryID int64, excludeID int64) (string, error) {
+	last, err := rs.repo.LastPosition(categoryID, excludeID)
+
+	if err != nil {
+		return "", err
+	}
+
+	return ordering.Between(last, "")
+}
+
+// ArchiveCompleted archives reminders that were completed more than the
+// configured number of days ago. A value of 0 turns auto-archiving off.
+func (rs *ReminderService) ArchiveCompleted() error {
+	days := utils.GetEnvInt64("AUTO_ARCHIVE_AFTER_DAYS", defaultAutoArchiveDays)
+
+	if days <= 0 {
+		return nil
+	}
+
+	now := utils.GetCurrentTime()
+
+	archived, err := rs.repo.ArchiveCompletedBefore(now.AddDate(0, 0, -int(days)), now)
+
+	if archived > 0 {
+		log.Printf("Archived %d completed reminders", archived)
+	}
+
+	return err
+}
+
+// ValidateReminderFilter checks a filter against the values the repository
+// knows how to query. Filters coming from the query string are already bound
+// with the same rules, but saved filters are loaded back from the database.
+func ValidateReminderFilter(filter models.ReminderFilter) error {
+	if filter.Status != "" && filter.Status != models.StatusPending && filter.Status != models.StatusCompleted {
+		return errors.New(utils.ErrorInvalidFilter)
+	}
+
+	if filter.Priority != "" && !utils.IsValidPriority(filter.Priority) {
+		return errors.New(utils.ErrorInvalidFilter)
+	}
+
+	if filter.TagMatch != "" && filter.TagMatch != models.TagMatchAny && filter.TagMatch != models.TagMatchAll {
+		return errors.New(utils.ErrorInvalidFilter)
+	}
+
+	if filter.DueWithin != "" && !utils.IsValidDueWithin(filter.DueWithin) {
+		return errors.New(utils.ErrorInvalidFilter)
+	}
+
+	if !utils.IsValidReminderSort(filter.Sort) {
+		return errors.New(utils.ErrorInvalidFilter)
+	}
+
+	if len(filter.Search) > 100 {
+		return errors.New(utils.ErrorInvalidFilter)
+	}
+
+	if filter.DueFrom != nil && filter.DueTo != nil && !filter.DueFrom.Before(*filter.DueTo) {
+		return errors.New(utils.ErrorInvalidFilter)
+	}
+
+	return nil
+}
+
+// resolveDueWindow turns a relative DueWithin window into a concrete due date
+// range, narrowing any absolute bounds that were already set.
+func resolveDueWindow(filter models.ReminderFilter, now time.Time) models.ReminderFilter {
+	if filter.DueWithin == "" {
+		return filter
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var from, to time.Time
+
+	switch filter.DueWithin {
+	case models.DueWithinOverdue:
+		to = now
+		filter.Status = models.StatusPending
+	case models.DueWithinToday:
+		from, to = today, today.AddDate(0, 0, 1)
+	case models.DueWithinTomorrow:
+		from, to = today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+	case models.DueWithinThisWeek:
+		// Weeks start on Monday
+		from = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		to = from.AddDate(0, 0, 7)
+	case models.DueWithinNext7Days:
+		from, to = now, now.AddDate(0, 0, 7)
+	case models.DueWithinThisMonth:
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		to = from.AddDate(0, 1, 0)
+	}
+
+	if !from.IsZero() && (filter.DueFrom == nil || filter.DueFrom.Before(from)) {
+		filter.DueFrom = &from
+	}
+
+	if !to.IsZero() && (filter.DueTo == nil || filter.DueTo.After(to)) {
+		filter.DueTo = &to
+	}
+
+	filter.DueWithin = ""
+
+	return filter
 }
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+type ReminderTemplateService struct {
+	db              *gorm.DB
+	repo            repository.ReminderTemplateRepository
+	reminderService *ReminderService
+}
+
+func NewReminderTemplateService(db *gorm.DB) *ReminderTemplateService {
+	return &ReminderTemplateService{
+		db:              db,
+		repo:            repository.NewReminderTemplateRepository(db),
+		reminderService: NewReminderService(db),
+	}
+}
+
+func (ts *ReminderTemplateService) List(userID int64) ([]models.ReminderTemplate, error) {
+	templates, err := ts.repo.FindByUserID(userID)
+
+	if err != nil {
+		return []models.ReminderTemplate{}, err
+	}
+
+	return templates, nil
+}
+
+func (ts *ReminderTemplateService) Get(userID int64, id int64) (models.ReminderTemplate, error) {
+	template, err := ts.repo.FindByID(id)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && template.UserID != userID) {
+		return models.ReminderTemplate{}, errors.New(utils.ErrorTemplateNotFound)
+	}
+
+	if err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func (ts *ReminderTemplateService) Create(userID int64, request models.ReminderTemplateCreateRequest) (models.ReminderTemplate, error) {
+	newTemplate := models.ReminderTemplate{
+		Name:             request.Name,
+		Title:            request.Title,
+		Description:      request.Description,
+		CategoryID:       request.CategoryID,
+		Priority:         request.Priority,
+		IsRecurring:      request.IsRecurring,
+		RecurringPattern: request.RecurringPattern,
+		AutoComplete:     request.AutoComplete,
+		DueOffsetMinutes: request.DueOffsetMinutes,
+		Items:            request.Items,
+		TagIDs:           request.TagIDs,
+		UserID:           userID,
+	}
+
+	if err := ts.validate(&newTemplate); err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	template, err := ts.repo.Create(newTemplate)
+
+	return template, err
+}
+
+func (ts *ReminderTemplateService) Update(userID int64, id int64, request models.ReminderTemplateUpdateRequest) (models.ReminderTemplate, error) {
+	template, err := ts.Get(userID, id)
+
+	if err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	if request.Name != nil {
+		template.Name = *request.Name
+	}
+
+	if request.Title != nil {
+		template.Title = *request.Title
+	}
+
+	if request.Description != nil {
+		template.Description = *request.Description
+	}
+
+	if request.CategoryID != nil {
+		template.CategoryID = *request.CategoryID
+	}
+
+	if request.Priority != nil {
+		template.Priority = *request.Priority
+	}
+
+	if request.IsRecurring != nil {
+		template.IsRecurring = *request.IsRecurring
+	}
+
+	if request.RecurringPattern != nil {
+		template.RecurringPattern = *request.RecurringPattern
+	}
+
+	if request.AutoComplete != nil {
+		template.AutoComplete = *request.AutoComplete
+	}
+
+	if request.DueOffsetMinutes != nil {
+		template.DueOffsetMinutes = *request.DueOffsetMinutes
+	}
+
+	if request.Items != nil {
+		template.Items = *request.Items
+	}
+
+	if request.TagIDs != nil {
+		template.TagIDs = *request.TagIDs
+	}
+
+	if err := ts.validate(&template); err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	updatedTemplate, err := ts.repo.Update(template)
+
+	return updatedTemplate, err
+}
+
+func (ts *ReminderTemplateService) Delete(userID int64, id int64) error {
+	if _, err := ts.Get(userID, id); err != nil {
+		return err
+	}
+
+	return ts.repo.Delete(id)
+}
+
+// Instantiate creates a reminder, with its checklist and tags, from a
+// template. The due date is the template's offset from the requested date.
+func (ts *ReminderTemplateService) Instantiate(userID int64, id int64, request models.ReminderFromTemplateRequest) (models.Reminder, error) {
+	template, err := ts.Get(userID, id)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	date := utils.GetCurrentTime()
+
+	if request.Date != nil {
+		date = *request.Date
+	}
+
+	variables := map[string]string{
+		"date":  date.Format("2006-01-02"),
+		"month": date.Format("January"),
+		"year":  date.Format("2006"),
+	}
+
+	for name, value := range request.Variables {
+		variables[name] = value
+	}
+
+	// Tags deleted since the template was saved are skipped
+	tags, err := ts.reminderService.tagRepo.FindByIDs(userID, template.TagIDs)
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	tagIDs := make([]int64, len(tags))
+
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+
+	dueDate := date.Add(time.Duration(template.DueOffsetMinutes) * time.Minute)
+
+	var reminder models.Reminder
+
+	err = ts.db.Transaction(func(tx *gorm.DB) error {
+		reminder, err = NewReminderService(tx).Create(userID, models.ReminderCreateRequest{
+			Title:            expandTemplate(template.Title, variables),
+			Description:      expandTemplate(template.Description, variables),
+			CategoryID:       template.CategoryID,
+			DueDate:          &dueDate,
+			Priority:         template.Priority,
+			IsRecurring:      template.IsRecurring,
+			RecurringPattern: template.RecurringPattern,
+			AutoComplete:     template.AutoComplete,
+			TagIDs:           tagIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		itemRepo := repository.NewReminderItemRepository(tx)
+
+		for i, text := range template.Items {
+			item := models.ReminderItem{
+				ReminderID: reminder.ID,
+				Text:       expandTemplate(text, variables),
+				Position:   i,
+			}
+
+			if _, err := itemRepo.Create(item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	return ts.reminderService.Get(reminder.ID)
+}
+
+// validate checks the category and tags of a template and normalises its
+// lists so they are stored as empty arrays rather than null.
+func (ts *ReminderTemplateService) validate(template *models.ReminderTemplate) error {
+	if _, err := NewCategoryService(ts.db).GetForUser(template.UserID, template.CategoryID); err != nil {
+		return err
+	}
+
+	if _, err := ts.reminderService.findTags(template.UserID, template.TagIDs); err != nil {
+		return err
+	}
+
+	if template.Items == nil {
+		template.Items = []string{}
+	}
+
+	if template.TagIDs == nil {
+		template.TagIDs = []int64{}
+	}
+
+	return nil
+}
+
+// expandTemplate replaces {{name}} placeholders with their variable. Unknown
+// placeholders are left untouched.
+func expandTemplate(text string, variables map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templateVariablePattern.FindStringSubmatch(placeholder)[1]
+
+		if value, ok := variables[name]; ok {
+			return value
+		}
+
+		return placeholder
+	})
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type SavedFilterService struct {
+	repo            repository.SavedFilterRepository
+	reminderService *ReminderService
+}
+
+func NewSavedFilterService(db *gorm.DB) *SavedFilterService {
+	return &SavedFilterService{
+		repo:            repository.NewSavedFilterRepository(db),
+		reminderService: NewReminderService(db),
+	}
+}
+
+func (ss *SavedFilterService) List(userID int64) ([]models.SavedFilter, error) {
+	savedFilters, err := ss.repo.FindByUserID(userID)
+
+	if err != nil {
+		return []models.SavedFilter{}, err
+	}
+
+	return savedFilters, nil
+}
+
+func (ss *SavedFilterService) Get(userID int64, id int64) (models.SavedFilter, error) {
+	savedFilter, err := ss.repo.FindByID(id)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && savedFilter.UserID != userID) {
+		return models.SavedFilter{}, errors.New(utils.ErrorSavedFilterNotFound)
+	}
+
+	if err != nil {
+		return models.SavedFilter{}, err
+	}
+
+	return savedFilter, nil
+}
+
+func (ss *SavedFilterService) Create(userID int64, request models.SavedFilterCreateRequest) (models.SavedFilter, error) {
+	if err := ValidateReminderFilter(request.Filter); err != nil {
+		return models.SavedFilter{}, err
+	}
+
+	newSavedFilter := models.SavedFilter{
+		Name:   request.Name,
+		Filter: request.Filter,
+		UserID: userID,
+	}
+
+	savedFilter, err := ss.repo.Create(newSavedFilter)
+
+	return savedFilter, err
+}
+
+func (ss *SavedFilterService) Update(userID int64, id int64, request models.SavedFilterUpdateRequest) (models.SavedFilter, error) {
+	savedFilter, err := ss.Get(userID, id)
+
+	if err != nil {
+		return models.SavedFilter{}, err
+	}
+
+	if request.Name != nil {
+		savedFilter.Name = *request.Name
+	}
+
+	if request.Filter != nil {
+		if err := ValidateReminderFilter(*request.Filter); err != nil {
+			return models.SavedFilter{}, err
+		}
+
+		savedFilter.Filter = *request.Filter
+	}
+
+	updatedSavedFilter, err := ss.repo.Update(savedFilter)
+
+	return updatedSavedFilter, err
+}
+
+func (ss *SavedFilterService) Delete(userID int64, id int64) error {
+	if _, err := ss.Get(userID, id); err != nil {
+		return err
+	}
+
+	return ss.repo.Delete(id)
+}
+
+// Reminders evaluates a saved filter against the owner's reminders.
+func (ss *SavedFilterService) Reminders(userID int64, id int64) ([]models.Reminder, error) {
+	savedFilter, err := ss.Get(userID, id)
+
+	if err != nil {
+		return []models.Reminder{}, err
+	}
+
+	return ss.reminderService.List(int32(userID), savedFilter.Filter)
+}
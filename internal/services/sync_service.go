@@ -0,0 +1,532 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+)
+
+const defaultSyncPageSize = 500
+
+// syncTrackedFields are the fields whose changes the change log records one
+// by one. A pushed field outside this list conflicts with any server change.
+var syncTrackedFields = map[string]map[string]bool{
+	models.SyncEntityReminder: {
+		"title": true, "description": true, "category_id": true, "due_date": true, "priority": true,
+		"status": true, "is_recurring": true, "recurring_pattern": true, "auto_complete": true,
+		"pinned": true, "flagged": true, "start_date": true, "estimated_minutes": true,
+	},
+	models.SyncEntityCategory: {"name": true, "color": true, "icon": true, "parent_id": true},
+	models.SyncEntityItem:     {"text": true, "done": true, "position": true},
+}
+
+// syncReferenceFields may hold the client id of an entity created earlier
+// in the same push instead of a server id.
+var syncReferenceFields = []string{"category_id", "reminder_id", "parent_id"}
+
+type SyncService struct {
+	repo            repository.SyncRepository
+	itemRepo        repository.ReminderItemRepository
+	reminderService *ReminderService
+	categoryService *CategoryService
+	itemService     *ReminderItemService
+}
+
+func NewSyncService(db *gorm.DB) *SyncService {
+	return &SyncService{
+		repo:            repository.NewSyncRepository(db),
+		itemRepo:        repository.NewReminderItemRepository(db),
+		reminderService: NewReminderService(db),
+		categoryService: NewCategoryService(db),
+		itemService:     NewReminderItemService(db),
+	}
+}
+
+// Pull returns the entities of the user that changed after the token. An
+// entity changed several times shows up once with its current state, as
+// created if it was created or restored after the token.
+func (ss *SyncService) Pull(userID int64, query models.SyncPullQuery) (models.SyncPullResponse, error) {
+	since, err := parseSyncToken(query.Since)
+
+	if err != nil {
+		return models.SyncPullResponse{}, err
+	}
+
+	limit := query.Limit
+
+	if limit == 0 {
+		limit = defaultSyncPageSize
+	}
+
+	entries, err := ss.repo.FindChanges(userID, since, limit+1)
+
+	if err != nil {
+		return models.SyncPullResponse{}, err
+	}
+
+	response := models.SyncPullResponse{
+		Token:   strconv.FormatInt(since, 10),
+		HasMore: len(entries) > limit,
+	}
+
+	if response.HasMore {
+		entries = entries[:limit]
+	}
+
+	// Per entity type, the changed ids and whether each was created
+	changed := map[string]map[int64]bool{}
+
+	for _, entry := range entries {
+		if changed[entry.EntityType] == nil {
+			changed[entry.EntityType] = map[int64]bool{}
+		}
+
+		ids := changed[entry.EntityType]
+		ids[entry.EntityID] = ids[entry.EntityID] || entry.Operation == models.ChangeOperationCreate
+	}
+
+	if len(entries) > 0 {
+		response.Token = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+	}
+
+	if response.Reminders, err = collectChanges(changed[models.SyncEntityReminder], ss.repo.FindReminders, func(r models.Reminder) int64 { return r.ID }); err != nil {
+		return models.SyncPullResponse{}, err
+	}
+
+	if response.Categories, err = collectChanges(changed[models.SyncEntityCategory], ss.repo.FindCategories, func(c models.Category) int64 { return c.ID }); err != nil {
+		return models.SyncPullResponse{}, err
+	}
+
+	if response.Items, err = collectChanges(changed[models.SyncEntityItem], ss.repo.FindItems, func(i models.ReminderItem) int64 { return i.ID }); err != nil {
+		return models.SyncPullResponse{}, err
+	}
+
+	if response.Notes, err = collectChanges(changed[models.SyncEntityNote], ss.repo.FindNotes, func(n models.ReminderNote) int64 { return n.ID }); err != nil {
+		return models.SyncPullResponse{}, err
+	}
+
+	if response.Attachments, err = collectChanges(changed[models.SyncEntityAttachment], ss.repo.FindAttachments, func(a models.Attachment) int64 { return a.ID }); err != nil {
+		return models.SyncPullResponse{}, err
+	}
+
+	if response.Tags, err = collectChanges(changed[models.SyncEntityTag], ss.repo.FindTags, func(t models.Tag) int64 { return t.ID }); err != nil {
+		return models.SyncPullResponse{}, err
+	}
+
+	return response, nil
+}
+
+// Push applies a batch of offline mutations in order. Each one succeeds or
+// fails on its own. When the server changed an entity after the client's
+// token, each pushed field is compared with the server's last change to it
+// and the later write wins.
+func (ss *SyncService) Push(userID int64, request models.SyncPushRequest) (models.SyncPushResponse, error) {
+	since, err := parseSyncToken(request.Since)
+
+	if err != nil {
+		return models.SyncPushResponse{}, err
+	}
+
+	// Changes made by this push itself must not count as conflicts
+	upTo, err := ss.repo.LatestChangeID()
+
+	if err != nil {
+		return models.SyncPushResponse{}, err
+	}
+
+	created := map[string]int64{}
+	results := make([]models.SyncMutationResult, 0, len(request.Mutations))
+
+	for _, mutation := range request.Mutations {
+		result := ss.apply(userID, mutation, since, upTo, created)
+
+		if result.Status == models.SyncStatusApplied && mutation.Operation == models.ChangeOperationCreate && mutation.ClientID != "" {
+			created[mutation.ClientID] = result.ID
+		}
+
+		results = append(results, result)
+	}
+
+	return models.SyncPushResponse{Results: results}, nil
+}
+
+func (ss *SyncService) apply(userID int64, mutation models.SyncMutation, since int64, upTo int64, created map[string]int64) models.SyncMutationResult {
+	result := models.SyncMutationResult{
+		ClientID:  mutation.ClientID,
+		Entity:    mutation.Entity,
+		Operation: mutation.Operation,
+		ID:        mutation.ID,
+	}
+
+	fail := func(err error) models.SyncMutationResult {
+		result.Status = models.SyncStatusFailed
+		result.Error = err.Error()
+
+		return result
+	}
+
+	fields, err := resolveSyncReferences(mutation.Fields, created)
+
+	if err != nil {
+		return fail(err)
+	}
+
+	if mutation.Operation == models.ChangeOperationCreate {
+		if result.ID, err = ss.create(userID, mutation.Entity, fields); err != nil {
+			return fail(err)
+		}
+
+		result.Status = models.SyncStatusApplied
+
+		return result
+	}
+
+	if err := ss.checkOwner(userID, mutation.Entity, mutation.ID); err != nil {
+		return fail(err)
+	}
+
+	entries, err := ss.repo.FindEntityChanges(mutation.Entity, mutation.ID, since, upTo)
+
+	if err != nil {
+		return fail(err)
+	}
+
+	modifiedAt := mutation.ModifiedAt
+
+	// A client clock running ahead must not win every conflict
+	if now := utils.GetCurrentTime(); modifiedAt.After(now) {
+		modifiedAt = now
+	}
+
+	if mutation.Operation == models.ChangeOperationDelete {
+		if len(entries) > 0 && entries[len(entries)-1].CreatedAt.After(modifiedAt) {
+			result.Status = models.SyncStatusRejected
+			result.Conflicts = []models.SyncFieldConflict{{
+				ServerChangedAt: entries[len(entries)-1].CreatedAt,
+				Winner:          models.SyncWinnerServer,
+			}}
+
+			return result
+		}
+
+		if err := ss.delete(userID, mutation.Entity, mutation.ID); err != nil {
+			return fail(err)
+		}
+
+		result.Status = models.SyncStatusApplied
+
+		return result
+	}
+
+	winning, conflicts := resolveSyncFields(mutation.Entity, fields, entries, modifiedAt)
+	result.Conflicts = conflicts
+	result.Status = models.SyncStatusApplied
+
+	if len(winning) < len(fields) {
+		result.Status = models.SyncStatusMerged
+	}
+
+	if len(winning) == 0 && len(fields) > 0 {
+		result.Status = models.SyncStatusRejected
+
+		return result
+	}
+
+	if err := ss.update(userID, mutation.Entity, mutation.ID, winning); err != nil {
+		return fail(err)
+	}
+
+	return result
+}
+
+func (ss *SyncService) create(userID int64, entity string, fields map[string]json.RawMessage) (int64, error) {
+	switch entity {
+	case models.SyncEntityReminder:
+		var request models.ReminderCreateRequest
+
+		if err := decodeSyncFields(fields, &request); err != nil {
+			return 0, err
+		}
+
+		reminder, err := ss.reminderService.Create(userID, request)
+
+		return reminder.ID, err
+	case models.SyncEntityCategory:
+		var request models.CategoryCreateRequest
+
+		if err := decodeSyncFields(fields, &request); err != nil {
+			return 0, err
+		}
+
+		category, err := ss.categoryService.Create(userID, request)
+
+		return category.ID, err
+	default:
+		var request struct {
+			models.ReminderItemCreateRequest
+			ReminderID int64 `json:"reminder_id" binding:"required"`
+		}
+
+		if err := decodeSyncFields(fields, &request); err != nil {
+			return 0, err
+		}
+
+		item, err := ss.itemService.Create(userID, request.ReminderID, request.ReminderItemCreateRequest)
+
+		return item.ID, err
+	}
+}
+
+func (ss *SyncService) update(userID int64, entity string, id int64, fields map[string]json.RawMessage) error {
+	switch entity {
+	case models.SyncEntityReminder:
+		var request models.ReminderUpdateRequest
+
+		if err := decodeSyncFields(fields, &request); err != nil {
+			return err
+		}
+
+		_, err := ss.reminderService.Update(id, request)
+
+		return err
+	case models.SyncEntityCategory:
+		var request models.CategoryUpdateRequest
+
+		if err := decodeSyncFields(fields, &request); err != nil {
+			return err
+		}
+
+		_, err := ss.categoryService.Update(userID, id, request)
+
+		return err
+	default:
+		var request models.ReminderItemUpdateRequest
+
+		if err := decodeSyncFields(fields, &request); err != nil {
+			return err
+		}
+
+		item, err := ss.itemRepo.FindByID(id)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = ss.itemService.Update(userID, item.ReminderID, id, request)
+
+		return err
+	}
+}
+
+func (ss *SyncService) delete(userID int64, entity string, id int64) error {
+	switch entity {
+	case models.SyncEntityReminder:
+		return ss.reminderService.Delete(id)
+	case models.SyncEntityCategory:
+		// Offline clients can't pick a strategy, so a category in use is kept
+		return ss.categoryService.Delete(userID, id, models.CategoryDeleteQuery{})
+	default:
+		item, err := ss.itemRepo.FindByID(id)
+
+		if err != nil {
+			return err
+		}
+
+		return ss.itemService.Delete(userID, item.ReminderID, id)
+	}
+}
+
+// checkOwner makes sure the entity exists and belongs to the user.
+func (ss *SyncService) checkOwner(userID int64, entity string, id int64) error {
+	switch entity {
+	case models.SyncEntityReminder:
+		_, err := ss.reminderService.GetForUser(userID, id)
+
+		return err
+	case models.SyncEntityCategory:
+		_, err := ss.categoryService.GetForUser(userID, id)
+
+		return err
+	default:
+		item, err := ss.itemRepo.FindByID(id)
+
+		if err != nil {
+			return errors.New(utils.ErrorItemNotFound)
+		}
+
+		_, err = ss.reminderService.GetForUser(userID, item.ReminderID)
+
+		return err
+	}
+}
+
+// resolveSyncFields splits the pushed fields into the ones that win and the
+// conflicts with server changes made since the client's token.
+func resolveSyncFields(entity string, fields map[string]json.RawMessage, entries []models.ChangeLogEntry, modifiedAt time.Time) (map[string]json.RawMessage, []models.SyncFieldConflict) {
+	serverChanges := map[string]time.Time{}
+	var lastChange time.Time
+
+	for _, entry := range entries {
+		lastChange = entry.CreatedAt
+
+		for _, field := range strings.Split(entry.Fields, ",") {
+			if field != "" {
+				serverChanges[field] = entry.CreatedAt
+			}
+		}
+	}
+
+	winning := map[string]json.RawMessage{}
+	conflicts := []models.SyncFieldConflict{}
+
+	for field, value := range fields {
+		changedAt, changed := serverChanges[field]
+
+		if !changed && !syncTrackedFields[entity][field] && len(entries) > 0 {
+			changedAt, changed = lastChange, true
+		}
+
+		if !changed {
+			winning[field] = value
+			continue
+		}
+
+		conflict := models.SyncFieldConflict{
+			Field:           field,
+			ClientValue:     value,
+			ServerChangedAt: changedAt,
+			Winner:          models.SyncWinnerClient,
+		}
+
+		if changedAt.After(modifiedAt) {
+			conflict.Winner = models.SyncWinnerServer
+		} else {
+			winning[field] = value
+		}
+
+		conflicts = append(conflicts, conflict)
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Field < conflicts[j].Field
+	})
+
+	return winning, conflicts
+}
+
+// resolveSyncReferences swaps client ids of entities created earlier in the
+// push for their new server ids.
+func resolveSyncReferences(fields map[string]json.RawMessage, created map[string]int64) (map[string]json.RawMessage, error) {
+	resolved := make(map[string]json.RawMessage, len(fields))
+
+	for field, value := range fields {
+		resolved[field] = value
+	}
+
+	for _, field := range syncReferenceFields {
+		var clientID string
+
+		if json.Unmarshal(resolved[field], &clientID) != nil {
+			continue
+		}
+
+		id, ok := created[clientID]
+
+		if !ok {
+			return nil, errors.New(utils.ErrorSyncUnknownReference)
+		}
+
+		resolved[field] = json.RawMessage(strconv.FormatInt(id, 10))
+	}
+
+	return resolved, nil
+}
+
+// decodeSyncFields fills a create or update request from pushed fields and
+// validates it like the regular endpoints do.
+func decodeSyncFields(fields map[string]json.RawMessage, request any) error {
+	encoded, err := json.Marshal(fields)
+
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(encoded, request); err != nil {
+		return err
+	}
+
+	return binding.Validator.ValidateStruct(request)
+}
+
+// collectChanges loads the changed entities of one type and sorts them into
+// created, updated and deleted. Whatever can't be loaded anymore is deleted.
+func collectChanges[T any](changed map[int64]bool, find func(ids []int64) ([]T, error), idOf func(entity T) int64) (models.SyncEntityChanges[T], error) {
+	changes := models.SyncEntityChanges[T]{
+		Created: []T{},
+		Updated: []T{},
+		Deleted: []int64{},
+	}
+
+	if len(changed) == 0 {
+		return changes, nil
+	}
+
+	ids := make([]int64, 0, len(changed))
+
+	for id := range changed {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	entities, err := find(ids)
+
+	if err != nil {
+		return changes, err
+	}
+
+	sort.Slice(entities, func(i, j int) bool { return idOf(entities[i]) < idOf(entities[j]) })
+
+	found := make(map[int64]bool, len(entities))
+
+	for _, entity := range entities {
+		found[idOf(entity)] = true
+
+		if changed[idOf(entity)] {
+			changes.Created = append(changes.Created, entity)
+		} else {
+			changes.Updated = append(changes.Updated, entity)
+		}
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			changes.Deleted = append(changes.Deleted, id)
+		}
+	}
+
+	return changes, nil
+}
+
+func parseSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	since, err := strconv.ParseInt(token, 10, 64)
+
+	if err != nil || since < 0 {
+		return 0, errors.New(utils.ErrorInvalidSyncToken)
+	}
+
+	return since, nil
+}
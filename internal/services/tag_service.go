@@ -0,0 +1,111 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type TagService struct {
+	repo repository.TagRepository
+}
+
+func NewTagService(db *gorm.DB) *TagService {
+	return &TagService{
+		repo: repository.NewTagRepository(db),
+	}
+}
+
+func (ts *TagService) List(userID int64) ([]models.Tag, error) {
+	tags, err := ts.repo.FindByUserID(userID)
+
+	if err != nil {
+		return []models.Tag{}, err
+	}
+
+	return tags, nil
+}
+
+func (ts *TagService) Get(userID int64, id int64) (models.Tag, error) {
+	tag, err := ts.repo.FindByID(id)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && tag.UserID != userID) {
+		return models.Tag{}, errors.New(utils.ErrorTagNotFound)
+	}
+
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+func (ts *TagService) Create(userID int64, request models.TagCreateRequest) (models.Tag, error) {
+	if err := ts.checkNameAvailable(userID, 0, request.Name); err != nil {
+		return models.Tag{}, err
+	}
+
+	newTag := models.Tag{
+		Name:   request.Name,
+		Color:  request.Color,
+		UserID: userID,
+	}
+
+	tag, err := ts.repo.Create(newTag)
+
+	return tag, err
+}
+
+func (ts *TagService) Update(userID int64, id int64, request models.TagUpdateRequest) (models.Tag, error) {
+	tag, err := ts.Get(userID, id)
+
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	if request.Name != nil {
+		if err := ts.checkNameAvailable(userID, id, *request.Name); err != nil {
+			return models.Tag{}, err
+		}
+
+		tag.Name = *request.Name
+	}
+
+	if request.Color != nil {
+		tag.Color = *request.Color
+	}
+
+	updatedTag, err := ts.repo.Update(tag)
+
+	return updatedTag, err
+}
+
+func (ts *TagService) Delete(userID int64, id int64) error {
+	if _, err := ts.Get(userID, id); err != nil {
+		return err
+	}
+
+	return ts.repo.Delete(id)
+}
+
+// checkNameAvailable makes sure no other tag of the user already has the name.
+func (ts *TagService) checkNameAvailable(userID int64, id int64, name string) error {
+	existing, err := ts.repo.FindByName(userID, name)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if existing.ID != id {
+		return errors.New(utils.ErrorTagAlreadyExists)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"errors"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TimeEntryService struct {
+	repo            repository.TimeEntryRepository
+	reminderService *ReminderService
+	categoryService *CategoryService
+}
+
+func NewTimeEntryService(db *gorm.DB) *TimeEntryService {
+	return &TimeEntryService{
+		repo:            repository.NewTimeEntryRepository(db),
+		reminderService: NewReminderService(db),
+		categoryService: NewCategoryService(db),
+	}
+}
+
+func (ts *TimeEntryService) List(userID int64, reminderID int64) ([]models.TimeEntry, error) {
+	if _, err := ts.reminderService.GetForUser(userID, reminderID); err != nil {
+		return []models.TimeEntry{}, err
+	}
+
+	entries, err := ts.repo.FindByReminderID(reminderID)
+
+	if err != nil {
+		return []models.TimeEntry{}, err
+	}
+
+	return entries, nil
+}
+
+// Create logs time that wasn't tracked with the timer.
+func (ts *TimeEntryService) Create(userID int64, reminderID int64, request models.TimeEntryCreateRequest) (models.TimeEntry, error) {
+	if _, err := ts.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.TimeEntry{}, err
+	}
+
+	endedAt := request.EndedAt
+
+	if request.Minutes != nil {
+		end := request.StartedAt.Add(time.Duration(*request.Minutes) * time.Minute)
+		endedAt = &end
+	}
+
+	if endedAt == nil || !endedAt.After(request.StartedAt) {
+		return models.TimeEntry{}, errors.New(utils.ErrorInvalidTimeEntry)
+	}
+
+	return ts.repo.Create(models.TimeEntry{
+		UserID:          userID,
+		ReminderID:      reminderID,
+		StartedAt:       request.StartedAt,
+		EndedAt:         endedAt,
+		DurationSeconds: int64(endedAt.Sub(request.StartedAt).Seconds()),
+		Note:            request.Note,
+	})
+}
+
+func (ts *TimeEntryService) Delete(userID int64, reminderID int64, entryID int64) error {
+	if _, err := ts.reminderService.GetForUser(userID, reminderID); err != nil {
+		return err
+	}
+
+	entry, err := ts.repo.FindByID(entryID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && entry.ReminderID != reminderID) {
+		return errors.New(utils.ErrorTimeEntryNotFound)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return ts.repo.Delete(entryID)
+}
+
+// StartTimer starts tracking time on a reminder. A user can only run one
+// timer at a time, so the running one has to be stopped first.
+func (ts *TimeEntryService) StartTimer(userID int64, reminderID int64, request models.TimerStartRequest) (models.TimeEntry, error) {
+	if _, err := ts.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.TimeEntry{}, err
+	}
+
+	_, err := ts.repo.FindRunning(userID)
+
+	if err == nil {
+		return models.TimeEntry{}, errors.New(utils.ErrorTimerAlreadyRunning)
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.TimeEntry{}, err
+	}
+
+	return ts.repo.Create(models.TimeEntry{
+		UserID:     userID,
+		ReminderID: reminderID,
+		StartedAt:  utils.GetCurrentTime(),
+		Note:       request.Note,
+	})
+}
+
+func (ts *TimeEntryService) StopTimer(userID int64, reminderID int64) (models.TimeEntry, error) {
+	if _, err := ts.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.TimeEntry{}, err
+	}
+
+	entry, err := ts.repo.FindRunning(userID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && entry.ReminderID != reminderID) {
+		return models.TimeEntry{}, errors.New(utils.ErrorTimerNotRunning)
+	}
+
+	if err != nil {
+		return models.TimeEntry{}, err
+	}
+
+	now := utils.GetCurrentTime()
+	entry.EndedAt = &now
+	entry.DurationSeconds = int64(now.Sub(entry.StartedAt).Seconds())
+
+	return ts.repo.Update(entry)
+}
+
+func (ts *TimeEntryService) ReminderSummary(userID int64, reminderID int64) (models.TimeSummary, error) {
+	if _, err := ts.reminderService.GetForUser(userID, reminderID); err != nil {
+		return models.TimeSummary{}, err
+	}
+
+	summary, seconds, err := ts.repo.SumReminder(reminderID)
+
+	if err != nil {
+		return models.TimeSummary{}, err
+	}
+
+	return ts.addRunning(userID, summary, seconds, func(running models.TimeEntry) (bool, error) {
+		return running.ReminderID == reminderID, nil
+	})
+}
+
+func (ts *TimeEntryService) CategorySummary(userID int64, categoryID int64) (models.TimeSummary, error) {
+	if _, err := ts.categoryService.GetForUser(userID, categoryID); err != nil {
+		return models.TimeSummary{}, err
+	}
+
+	summary, seconds, err := ts.repo.SumCategory(userID, categoryID)
+
+	if err != nil {
+		return models.TimeSummary{}, err
+	}
+
+	return ts.addRunning(userID, summary, seconds, func(running models.TimeEntry) (bool, error) {
+		reminder, err := ts.reminderService.Get(running.ReminderID)
+
+		return reminder.CategoryID == categoryID, err
+	})
+}
+
+// addRunning counts the user's running timer up to now if it belongs to the
+// summary, and rounds the tracked time to whole minutes.
+func (ts *TimeEntryService) addRunning(userID int64, summary models.TimeSummary, seconds int64, belongs func(models.TimeEntry) (bool, error)) (models.TimeSummary, error) {
+	running, err := ts.repo.FindRunning(userID)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.TimeSummary{}, err
+	}
+
+	if err == nil {
+		ok, err := belongs(running)
+
+		if err != nil {
+			return models.TimeSummary{}, err
+		}
+
+		if ok {
+			seconds += int64(utils.GetCurrentTime().Sub(running.StartedAt).Seconds())
+			summary.RunningSince = &running.StartedAt
+		}
+	}
+
+	summary.TrackedMinutes = (seconds + 30) / 60
+
+	return summary, nil
+}
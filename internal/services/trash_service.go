@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"reminder-server/internal/models"
+	"reminder-server/internal/repository"
+	"reminder-server/internal/storage"
+	"reminder-server/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultTrashRetentionDays = 30
+
+type TrashService struct {
+	db              *gorm.DB
+	repo            repository.TrashRepository
+	attachmentRepo  repository.AttachmentRepository
+	categoryRepo    repository.CategoryRepository
+	reminderService *ReminderService
+	blobs           storage.BlobStore
+	retention       time.Duration
+}
+
+func NewTrashService(db *gorm.DB) *TrashService {
+	retentionDays := utils.GetEnvInt64("TRASH_RETENTION_DAYS", defaultTrashRetentionDays)
+
+	return &TrashService{
+		db:              db,
+		repo:            repository.NewTrashRepository(db),
+		attachmentRepo:  repository.NewAttachmentRepository(db),
+		categoryRepo:    repository.NewCategoryRepository(db),
+		reminderService: NewReminderService(db),
+		blobs:           storage.Default(),
+		retention:       time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+func (ts *TrashService) List(userID int64) (models.Trash, error) {
+	reminders, err := ts.repo.FindReminders(userID)
+
+	if err != nil {
+		return models.Trash{}, err
+	}
+
+	categories, err := ts.repo.FindCategories(userID)
+
+	if err != nil {
+		return models.Trash{}, err
+	}
+
+	return models.Trash{
+		Reminders:  reminders,
+		Categories: categories,
+	}, nil
+}
+
+// RestoreReminder takes a reminder out of the trash, restoring its category
+// too if that was deleted as well. Either both come back or neither does.
+func (ts *TrashService) RestoreReminder(userID int64, id int64) (models.Reminder, error) {
+	reminder, err := ts.repo.FindReminder(userID, id)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Reminder{}, errors.New(utils.ErrorReminderNotFound)
+	}
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	var categories []models.Category
+
+	if category, err := ts.repo.FindCategory(userID, reminder.CategoryID); err == nil {
+		if categories, err = ts.categoriesToRestore(userID, category); err != nil {
+			return models.Reminder{}, err
+		}
+	}
+
+	err = ts.db.Transaction(func(tx *gorm.DB) error {
+		trashRepo := repository.NewTrashRepository(tx)
+
+		for _, category := range categories {
+			if err := trashRepo.RestoreCategory(category.ID); err != nil {
+				return err
+			}
+		}
+
+		return trashRepo.RestoreReminder(reminder.ID)
+	})
+
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	return ts.reminderService.Get(reminder.ID)
+}
+
+func (ts *TrashService) RestoreCategory(userID int64, id int64) (models.Category, error) {
+	category, err := ts.repo.FindCategory(userID, id)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Category{}, errors.New(utils.ErrorCategoryNotFound)
+	}
+
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	categories, err := ts.categoriesToRestore(userID, category)
+
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	err = ts.db.Transaction(func(tx *gorm.DB) error {
+		trashRepo := repository.NewTrashRepository(tx)
+
+		for _, restored := range categories {
+			if err := trashRepo.RestoreCategory(restored.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	category.DeletedAt = gorm.DeletedAt{}
+	category.Version++
+
+	return category, nil
+}
+
+// categoriesToRestore returns the trashed category together with the parents
+// that are in the trash as well, so it isn't restored under a deleted one.
+// None of them may clash with the name of a category in use.
+func (ts *TrashService) categoriesToRestore(userID int64, category models.Category) ([]models.Category, error) {
+	categories := []models.Category{category}
+
+	for parentID := category.ParentID; parentID != nil; {
+		parent, err := ts.repo.FindCategory(userID, *parentID)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, parent)
+		parentID = parent.ParentID
+	}
+
+	for _, restored := range categories {
+		if err := ts.checkCategoryName(restored); err != nil {
+			return nil, err
+		}
+	}
+
+	return categories, nil
+}
+
+// checkCategoryName refuses to restore a category while another one of the
+// user has taken its name.
+func (ts *TrashService) checkCategoryName(category models.Category) error {
+	taken, err := ts.categoryRepo.NameTaken(category.UserID, category.Name, category.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if taken {
+		return errors.New(utils.ErrorCategoryNameTaken)
+	}
+
+	return nil
+}
+
+// Empty permanently deletes everything in a user's trash.
+func (ts *TrashService) Empty(userID int64) error {
+	trash, err := ts.List(userID)
+
+	if err != nil {
+		return err
+	}
+
+	reminderIDs := make([]int64, len(trash.Reminders))
+
+	for i, reminder := range trash.Reminders {
+		reminderIDs[i] = reminder.ID
+	}
+
+	categoryIDs := make([]int64, len(trash.Categories))
+
+	for i, category := range trash.Categories {
+		categoryIDs[i] = category.ID
+	}
+
+	if err := ts.purgeReminders(reminderIDs); err != nil {
+		return err
+	}
+
+	return ts.repo.PurgeCategories(categoryIDs)
+}
+
+// PurgeExpired permanently deletes items that have been in the trash for
+// longer than the retention period.
+func (ts *TrashService) PurgeExpired() error {
+	deletedBefore := utils.GetCurrentTime().Add(-ts.retention)
+
+	reminderIDs, err := ts.repo.FindExpiredReminderIDs(deletedBefore)
+
+	if err != nil {
+		return err
+	}
+
+	if err := ts.purgeReminders(reminderIDs); err != nil {
+		return err
+	}
+
+	categoryIDs, err := ts.repo.FindExpiredCategoryIDs(deletedBefore)
+
+	if err != nil {
+		return err
+	}
+
+	if len(reminderIDs) > 0 || len(categoryIDs) > 0 {
+		log.Printf("Purging %d reminders and %d categories from the trash", len(reminderIDs), len(categoryIDs))
+	}
+
+	return ts.repo.PurgeCategories(categoryIDs)
+}
+
+// purgeReminders hard-deletes reminders and then removes their attachment
+// blobs, unless copies of the attachments still use them. Blob failures are
+// only logged since nothing references them anymore.
+func (ts *TrashService) purgeReminders(ids []int64) error {
+	attachments, err := ts.attachmentRepo.FindByReminderIDs(ids)
+
+	if err != nil {
+		return err
+	}
+
+	if err := ts.repo.PurgeReminders(ids); err != nil {
+		return err
+	}
+
+	if ts.blobs == nil {
+		return nil
+	}
+
+	for _, attachment := range attachments {
+		count, err := ts.attachmentRepo.CountByStorageKey(attachment.StorageKey)
+
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			continue
+		}
+
+		if err := ts.blobs.Delete(context.Background(), attachment.StorageKey); err != nil {
+			log.Printf("Error deleting blob %s: %v", attachment.StorageKey, err)
+		}
+	}
+
+	return nil
+}
@@ -5,18 +5,22 @@ import (
 	"log"
 	"reminder-server/internal/models"
 	"reminder-server/internal/repository"
+	"reminder-server/internal/seed"
 	"reminder-server/internal/utils"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type UserService struct {
+	db   *gorm.DB
 	repo repository.UserRepository
 }
 
 func NewUserService(db *gorm.DB) *UserService {
 	return &UserService{
+		db:   db,
 		repo: repository.NewUserRepository(db),
 	}
 }
@@ -55,6 +59,11 @@ func (us *UserService) Create(request models.UserCreateRequest) (models.User, er
 	newUser := models.User{
 		Email:    request.Email,
 		Password: request.Password,
+		Timezone: request.Timezone,
+	}
+
+	if newUser.Timezone == "" {
+		newUser.Timezone = "UTC"
 	}
 
 	existingUser, err := us.GetByEmail(newUser.Email)
@@ -77,9 +86,84 @@ func (us *UserService) Create(request models.UserCreateRequest) (models.User, er
 
 	newUser.Password = string(hash)
 
-	user, err := us.repo.Create(newUser)
+	var user models.User
+
+	// A user is only created together with their default categories
+	err = us.db.Transaction(func(tx *gorm.DB) error {
+		userRepo := repository.NewUserRepository(tx)
+
+		if user, err = userRepo.Create(newUser); err != nil {
+			return err
+		}
+
+		return provisionCategories(tx, user.ID)
+	})
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
+// provisionCategories creates the seed categories for a new user.
+func provisionCategories(tx *gorm.DB, userID int64) error {
+	seeds := seed.DefaultCategories()
+
+	if len(seeds) == 0 {
+		return nil
+	}
+
+	categories := make([]models.Category, len(seeds))
+
+	for i, category := range seeds {
+		categories[i] = models.Category{
+			Name:          category.Name,
+			Color:         category.Color,
+			Icon:          category.Icon,
+			DefaultTagIDs: []int64{},
+			UserID:        userID,
+		}
+	}
+
+	categoryRepo := repository.NewCategoryRepository(tx)
+	_, err := categoryRepo.CreateBulk(categories)
+
+	return err
+}
+
+func (us *UserService) Update(id int64, request models.UserUpdateRequest) (models.User, error) {
+	user, err := us.Get(id)
+
+	if err != nil {
+		return models.User{}, errors.New(utils.ErrorUserNotFound)
+	}
+
+	if request.Timezone != nil {
+		user.Timezone = *request.Timezone
+	}
+
+	updatedUser, err := us.repo.Update(user)
+
+	return updatedUser, err
+}
+
+// Location returns the time zone reminders of a user are entered in. Users
+// without a valid zone get UTC.
+func (us *UserService) Location(id int64) *time.Location {
+	user, err := us.Get(id)
+
+	if err != nil || user.Timezone == "" {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(user.Timezone)
+
+	if err != nil {
+		return time.UTC
+	}
 
-	return user, err
+	return location
 }
 
 func (us *UserService) Login(request models.UserLoginRequest) (models.User, error) {
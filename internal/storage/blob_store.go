@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps the contents of uploaded files. Keys are generated by the
+// server and use forward slashes as separators.
+type BlobStore interface {
+	// Put stores size bytes read from r under key, replacing any existing blob
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns a seekable reader over the blob so it can be served with
+	// range support
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete removes the blob, succeeding if it doesn't exist
+	Delete(ctx context.Context, key string) error
+}
+
+var defaultStore BlobStore
+
+// SetDefault registers the blob store used by the services.
+func SetDefault(store BlobStore) {
+	defaultStore = store
+}
+
+// Default returns the blob store registered with SetDefault, or nil.
+func Default() BlobStore {
+	return defaultStore
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps blobs as files below a root directory.
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	absRoot, err := filepath.Abs(root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(absRoot, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalStore{
+		root: absRoot,
+	}, nil
+}
+
+func (ls *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := ls.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failed upload never leaves a
+	// partial blob behind
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.CopyN(tmp, r, size); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (ls *LocalStore) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	path, err := ls.path(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+
+	return file, err
+}
+
+func (ls *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := ls.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path maps a key to a file below the root, rejecting keys that would escape it.
+func (ls *LocalStore) path(key string) (string, error) {
+	path := filepath.Join(ls.root, filepath.FromSlash(key))
+
+	if !strings.HasPrefix(path, ls.root+string(filepath.Separator)) {
+		return "", errors.New("invalid blob key")
+	}
+
+	return path, nil
+}
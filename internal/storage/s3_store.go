@@ -0,0 +1,378 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unsignedPayload tells S3 the request body isn't part of the signature, which
+// lets uploads be streamed instead of hashed up front.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+type S3Config struct {
+	// Endpoint is the base URL of the service, e.g. https://s3.eu-west-1.amazonaws.com
+	// or http://localhost:9000 for a local stand-in
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle addresses objects as /bucket/key instead of using a
+	// bucket subdomain. Most S3-compatible servers expect it.
+	UsePathStyle bool
+}
+
+// S3Store keeps blobs in an S3-compatible bucket, signing requests with AWS
+// Signature Version 4.
+type S3Store struct {
+	config   S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewS3Store(config S3Config) (*S3Store, error) {
+	if config.Endpoint == "" || config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("S3 endpoint, bucket and credentials are required")
+	}
+
+	endpoint, err := url.Parse(config.Endpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	return &S3Store{
+		config:   config,
+		endpoint: endpoint,
+		client:   &http.Client{},
+		now:      time.Now,
+	}, nil
+}
+
+func (ss *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := ss.newRequest(ctx, http.MethodPut, key, io.LimitReader(r, size))
+
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := ss.do(req)
+
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+// Open checks the blob exists and returns a reader that fetches it lazily,
+// issuing a ranged GET from the current offset after every seek.
+func (ss *S3Store) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	req, err := ss.newRequest(ctx, http.MethodHead, key, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ss.do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body.Close()
+
+	return &s3Object{
+		ctx:   ctx,
+		store: ss,
+		key:   key,
+		size:  res.ContentLength,
+	}, nil
+}
+
+func (ss *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := ss.newRequest(ctx, http.MethodDelete, key, nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := ss.do(req)
+
+	if errors.Is(err, ErrBlobNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+func (ss *S3Store) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	objectURL := *ss.endpoint
+
+	if ss.config.UsePathStyle {
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + ss.config.Bucket + "/" + key
+		objectURL.RawPath = strings.TrimSuffix(objectURL.EscapedPath(), "/") + "/" + s3Escape(ss.config.Bucket) + "/" + s3EscapePath(key)
+	} else {
+		objectURL.Host = ss.config.Bucket + "." + objectURL.Host
+		objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + key
+		objectURL.RawPath = strings.TrimSuffix(objectURL.EscapedPath(), "/") + "/" + s3EscapePath(key)
+	}
+
+	return http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+}
+
+// do signs and sends a request, turning error statuses into errors.
+func (ss *S3Store) do(req *http.Request) (*http.Response, error) {
+	ss.sign(req, ss.now().UTC())
+
+	res, err := ss.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 300 {
+		return res, nil
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrBlobNotFound
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+	return nil, fmt.Errorf("s3 %s %s: %s %s", req.Method, req.URL.Path, res.Status, strings.TrimSpace(string(message)))
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request.
+func (ss *S3Store) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+
+	if payloadHash == "" {
+		payloadHash = unsignedPayload
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + ss.config.Region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+ss.config.SecretAccessKey), date)
+	key = hmacSHA256(key, ss.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		ss.config.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var parts []string
+
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+
+		for _, value := range values {
+			parts = append(parts, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes everything but the unreserved characters, as the
+// signature algorithm requires.
+func s3Escape(s string) string {
+	var escaped strings.Builder
+
+	for _, b := range []byte(s) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || strings.IndexByte("-._~", b) >= 0 {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
+
+func s3EscapePath(key string) string {
+	segments := strings.Split(key, "/")
+
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// s3Object reads an object through ranged GET requests so it can be seeked
+// without downloading what comes before the offset.
+type s3Object struct {
+	ctx    context.Context
+	store  *S3Store
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (so *s3Object) Read(p []byte) (int, error) {
+	if so.offset >= so.size {
+		return 0, io.EOF
+	}
+
+	if so.body == nil {
+		req, err := so.store.newRequest(so.ctx, http.MethodGet, so.key, nil)
+
+		if err != nil {
+			return 0, err
+		}
+
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(so.offset, 10)+"-")
+
+		res, err := so.store.do(req)
+
+		if err != nil {
+			return 0, err
+		}
+
+		so.body = res.Body
+	}
+
+	n, err := so.body.Read(p)
+	so.offset += int64(n)
+
+	return n, err
+}
+
+func (so *s3Object) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = so.offset + offset
+	case io.SeekEnd:
+		next = so.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+
+	if next < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if next != so.offset {
+		so.closeBody()
+		so.offset = next
+	}
+
+	return next, nil
+}
+
+func (so *s3Object) Close() error {
+	return so.closeBody()
+}
+
+func (so *s3Object) closeBody() error {
+	if so.body == nil {
+		return nil
+	}
+
+	err := so.body.Close()
+	so.body = nil
+
+	return err
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"regexp"
+	"slices"
+	"unicode/utf8"
+)
+
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// CategoryIconKeys are the named icons clients ship, usable in place of an
+// emoji.
+var CategoryIconKeys = []string{
+	"book", "briefcase", "calendar", "car", "cart", "code", "family", "finance", "flag", "food",
+	"gift", "health", "heart", "home", "inbox", "music", "person", "pets", "school", "sport",
+	"star", "travel", "work",
+}
+
+func IsValidHexColor(color string) bool {
+	return hexColorPattern.MatchString(color)
+}
+
+// IsValidCategoryIcon accepts a known icon key or exactly one emoji, which may
+// be a sequence such as a flag, a keycap or emojis joined into one.
+func IsValidCategoryIcon(icon string) bool {
+	return slices.Contains(CategoryIconKeys, icon) || isSingleEmoji(icon)
+}
+
+func isSingleEmoji(s string) bool {
+	if s == "" || !utf8.ValidString(s) {
+		return false
+	}
+
+	runes := []rune(s)
+
+	// Flags are a pair of regional indicators
+	if len(runes) == 2 && isRegionalIndicator(runes[0]) && isRegionalIndicator(runes[1]) {
+		return true
+	}
+
+	// Keycaps are a digit, # or * followed by the keycap mark
+	if (runes[0] >= '0' && runes[0] <= '9') || runes[0] == '#' || runes[0] == '*' {
+		rest := runes[1:]
+
+		if len(rest) > 0 && rest[0] == 0xFE0F {
+			rest = rest[1:]
+		}
+
+		return len(rest) == 1 && rest[0] == 0x20E3
+	}
+
+	// Anything else is one emoji, optionally joined to more by zero width
+	// joiners, each followed by its modifiers
+	expectEmoji := true
+
+	for _, r := range runes {
+		switch {
+		case expectEmoji:
+			if !isEmojiBase(r) {
+				return false
+			}
+
+			expectEmoji = false
+		case r == 0x200D:
+			expectEmoji = true
+		case !isEmojiModifier(r):
+			return false
+		}
+	}
+
+	return !expectEmoji
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isEmojiBase(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF && !isRegionalIndicator(r) && !(r >= 0x1F3FB && r <= 0x1F3FF):
+		return true
+	case r >= 0x2600 && r <= 0x27BF, r >= 0x2300 && r <= 0x23FF, r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	case r >= 0x2190 && r <= 0x21FF, r >= 0x25A0 && r <= 0x25FF, r >= 0x2934 && r <= 0x2935:
+		return true
+	}
+
+	return slices.Contains([]rune{0x00A9, 0x00AE, 0x203C, 0x2049, 0x2122, 0x2139, 0x3030, 0x303D, 0x3297, 0x3299}, r)
+}
+
+// isEmojiModifier matches what may follow an emoji within the same one:
+// variation selectors, skin tones and tag sequences as used by subdivision
+// flags.
+func isEmojiModifier(r rune) bool {
+	return r == 0xFE0E || r == 0xFE0F ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) ||
+		(r >= 0xE0020 && r <= 0xE007F)
+}
@@ -6,14 +6,73 @@ import (
 )
 
 const (
-	ErrorUserNotFound      = "User not found"
-	ErrorInvalidPassword   = "Invalid password"
-	ErrorUserAlreadyExists = "User already exists"
-	ErrorFailedToHash      = "Failed to hash password"
-	ErrorInternalServer    = "Internal server error"
-	ErrorCategoryNotFound  = "Category not found"
-	ErrorInvalidStatus     = "Invalid status"
-	ErrorInvalidPriority   = "Invalid priority"
+	ErrorUserNotFound             = "User not found"
+	ErrorInvalidPassword          = "Invalid password"
+	ErrorUserAlreadyExists        = "User already exists"
+	ErrorFailedToHash             = "Failed to hash password"
+	ErrorInternalServer           = "Internal server error"
+	ErrorCategoryNotFound         = "Category not found"
+	ErrorReminderNotFound         = "Reminder not found"
+	ErrorItemNotFound             = "Item not found"
+	ErrorInvalidItemOrder         = "Item order must list every item of the reminder exactly once"
+	ErrorInvalidStatus            = "Invalid status"
+	ErrorInvalidPriority          = "Invalid priority"
+	ErrorInvalidFilter            = "Invalid filter"
+	ErrorSavedFilterNotFound      = "Saved filter not found"
+	ErrorTagNotFound              = "Tag not found"
+	ErrorTagAlreadyExists         = "Tag already exists"
+	ErrorNoteNotFound             = "Note not found"
+	ErrorNoteNotAuthor            = "Only the author can change a note"
+	ErrorInvalidInclude           = "Invalid include"
+	ErrorAttachmentNotFound       = "Attachment not found"
+	ErrorAttachmentTooLarge       = "Attachment is too large"
+	ErrorAttachmentEmpty          = "Attachment is empty"
+	ErrorAttachmentType           = "Attachment type is not allowed"
+	ErrorAttachmentQuota          = "Attachment storage quota exceeded"
+	ErrorStorageNotConfigured     = "Attachment storage is not configured"
+	ErrorInvalidBulkRequest       = "Invalid bulk request"
+	ErrorBulkActionFailed         = "Bulk action failed, no reminders were changed"
+	ErrorReminderNoDueDate        = "Reminder has no due date"
+	ErrorTemplateNotFound         = "Template not found"
+	ErrorQuickAddNoTitle          = "Could not find a title in the text"
+	ErrorReminderBlocked          = "Reminder is blocked by pending reminders"
+	ErrorDependencyCycle          = "Dependency would create a cycle"
+	ErrorDependencyExists         = "Dependency already exists"
+	ErrorDependencyNotFound       = "Dependency not found"
+	ErrorRevisionNotFound         = "Revision not found"
+	ErrorRevisionNotRevertible    = "Revision can't be reverted to"
+	ErrorNothingToUndo            = "Nothing to undo"
+	ErrorVersionConflict          = "Resource was changed by another request"
+	ErrorPreconditionRequired     = "If-Match header is required"
+	ErrorInvalidIdempotencyKey    = "Idempotency key must be at most 255 characters"
+	ErrorIdempotencyKeyReused     = "Idempotency key was already used for a different request"
+	ErrorIdempotencyKeyInProgress = "A request with this idempotency key is still in progress"
+	ErrorRequestTooLarge          = "Request body is too large"
+	ErrorInvalidSyncToken         = "Invalid sync token"
+	ErrorSyncUnknownReference     = "Mutation references an unknown client id"
+	ErrorInvalidMove              = "Move needs a different reminder to place it next to or a category"
+	ErrorStartAfterDue            = "Start date can't be after the due date"
+	ErrorTimeEntryNotFound        = "Time entry not found"
+	ErrorTimerAlreadyRunning      = "A timer is already running"
+	ErrorTimerNotRunning          = "No timer is running for this reminder"
+	ErrorInvalidTimeEntry         = "Time entry must end after it starts"
+	ErrorCategoryNameRequired     = "Category name can't be blank"
+	ErrorCategoryNameTaken        = "A category with this name already exists"
+	ErrorInvalidCategoryColor     = "Color must be a hex color like #0077B6"
+	ErrorInvalidCategoryIcon      = "Icon must be a single emoji or a known icon key"
+	ErrorCategoryInUse            = "Category still has reminders, templates or subcategories"
+	ErrorInvalidReassignTarget    = "Reassign needs another of your categories outside this one as target"
+	ErrorInvalidCategoryParent    = "Parent must be another of your categories"
+	ErrorCategoryCycle            = "A category can't be placed inside itself or its subcategories"
+	ErrorCategoryTooDeep          = "Categories can't be nested that deep"
+	ErrorDueDateRequired          = "Due date is required unless the category has a default due time"
+	ErrorInvalidDueTime           = "Default due time must be a time of day like 09:00"
+	ErrorInvalidMergeSource       = "Merge sources must be other categories of yours that don't contain the target"
+)
+
+// Warnings are reported next to a successful result
+const (
+	WarningWIPLimitExceeded = "Category %q has %d pending reminders, more than its WIP limit of %d"
 )
 
 func ErrorSqlNoRows(err error) error {
@@ -5,13 +5,24 @@ import (
 	"os"
 	"reminder-server/internal/models"
 	"slices"
+	"strconv"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 var validPrioties = []string{models.PriorityLow, models.PriorityMedium, models.PriorityHigh}
 var valiudStatuses = []string{models.StatusPending, models.StatusCompleted, models.StatusOverdue}
+var validDueWithin = []string{
+	models.DueWithinOverdue,
+	models.DueWithinToday,
+	models.DueWithinTomorrow,
+	models.DueWithinThisWeek,
+	models.DueWithinNext7Days,
+	models.DueWithinThisMonth,
+}
+var validReminderSorts = []string{"", "due_date", "-due_date", "priority", "created_at", "-created_at", "title", "manual"}
 
 func GenerateToken(user models.User) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
@@ -45,3 +56,35 @@ func IsValidPriority(priority string) bool {
 func IsValidStatus(status string) bool {
 	return slices.Contains(valiudStatuses, status)
 }
+
+func IsValidDueWithin(window string) bool {
+	return slices.Contains(validDueWithin, window)
+}
+
+func IsValidReminderSort(sort string) bool {
+	return slices.Contains(validReminderSorts, sort)
+}
+
+// GetUserID returns the authenticated user's ID from the request context.
+// TODO: Drop the fallback once every route is behind RequireAuth
+func GetUserID(c *gin.Context) int64 {
+	userID := c.GetInt64("user_id")
+
+	if userID == 0 {
+		userID = 1 // Temporary hardcoded user ID for testing
+	}
+
+	return userID
+}
+
+// GetEnvInt64 reads an integer setting from the environment, falling back to
+// the default when it is unset or invalid.
+func GetEnvInt64(name string, fallback int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(name), 10, 64)
+
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE saved_filters (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    filter TEXT NOT NULL DEFAULT '{}',
+    user_id INTEGER NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_saved_filters_user_id ON saved_filters(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_saved_filters_user_id;
+DROP TABLE saved_filters;
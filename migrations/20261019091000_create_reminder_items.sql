@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE reminder_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    reminder_id INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME,
+    updated_at DATETIME,
+    FOREIGN KEY (reminder_id) REFERENCES reminders(id)
+);
+
+CREATE INDEX idx_reminder_items_reminder_id ON reminder_items(reminder_id);
+
+ALTER TABLE reminders ADD COLUMN auto_complete BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE reminders DROP COLUMN auto_complete;
+DROP INDEX IF EXISTS idx_reminder_items_reminder_id;
+DROP TABLE reminder_items;
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    color TEXT,
+    user_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE UNIQUE INDEX idx_tags_user_id_name ON tags(user_id, name COLLATE NOCASE);
+
+CREATE TABLE reminder_tags (
+    reminder_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY (reminder_id, tag_id),
+    FOREIGN KEY (reminder_id) REFERENCES reminders(id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id)
+);
+
+CREATE INDEX idx_reminder_tags_tag_id ON reminder_tags(tag_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reminder_tags_tag_id;
+DROP TABLE reminder_tags;
+DROP INDEX IF EXISTS idx_tags_user_id_name;
+DROP TABLE tags;
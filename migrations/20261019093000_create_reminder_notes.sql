@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE reminder_notes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    reminder_id INTEGER NOT NULL,
+    author_id INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME,
+    FOREIGN KEY (reminder_id) REFERENCES reminders(id),
+    FOREIGN KEY (author_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_reminder_notes_reminder_id ON reminder_notes(reminder_id);
+
+CREATE TABLE reminder_note_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    note_id INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME,
+    FOREIGN KEY (note_id) REFERENCES reminder_notes(id)
+);
+
+CREATE INDEX idx_reminder_note_revisions_note_id ON reminder_note_revisions(note_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reminder_note_revisions_note_id;
+DROP TABLE reminder_note_revisions;
+DROP INDEX IF EXISTS idx_reminder_notes_reminder_id;
+DROP TABLE reminder_notes;
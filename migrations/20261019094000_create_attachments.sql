@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    reminder_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at DATETIME,
+    FOREIGN KEY (reminder_id) REFERENCES reminders(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_attachments_reminder_id ON attachments(reminder_id);
+CREATE INDEX idx_attachments_user_id ON attachments(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_attachments_user_id;
+DROP INDEX IF EXISTS idx_attachments_reminder_id;
+DROP TABLE attachments;
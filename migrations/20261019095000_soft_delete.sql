@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE reminders ADD COLUMN deleted_at DATETIME;
+ALTER TABLE categories ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX idx_reminders_deleted_at ON reminders(deleted_at);
+CREATE INDEX idx_categories_deleted_at ON categories(deleted_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+DROP INDEX IF EXISTS idx_reminders_deleted_at;
+ALTER TABLE categories DROP COLUMN deleted_at;
+ALTER TABLE reminders DROP COLUMN deleted_at;
@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE reminders ADD COLUMN completed_at DATETIME;
+ALTER TABLE reminders ADD COLUMN archived_at DATETIME;
+
+UPDATE reminders SET completed_at = updated_at WHERE status = 'completed';
+
+CREATE INDEX idx_reminders_archived_at ON reminders(archived_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reminders_archived_at;
+ALTER TABLE reminders DROP COLUMN archived_at;
+ALTER TABLE reminders DROP COLUMN completed_at;
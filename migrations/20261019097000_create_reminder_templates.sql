@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE reminder_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    category_id INTEGER NOT NULL,
+    priority TEXT NOT NULL,
+    is_recurring BOOLEAN NOT NULL DEFAULT FALSE,
+    recurring_pattern TEXT,
+    auto_complete BOOLEAN NOT NULL DEFAULT FALSE,
+    due_offset_minutes INTEGER NOT NULL DEFAULT 0,
+    items TEXT NOT NULL DEFAULT '[]',
+    tag_ids TEXT NOT NULL DEFAULT '[]',
+    user_id INTEGER NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME,
+    FOREIGN KEY (category_id) REFERENCES categories(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_reminder_templates_user_id ON reminder_templates(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reminder_templates_user_id;
+DROP TABLE reminder_templates;
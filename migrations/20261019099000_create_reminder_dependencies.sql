@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE reminder_dependencies (
+    reminder_id INTEGER NOT NULL,
+    blocked_by_id INTEGER NOT NULL,
+    created_at DATETIME,
+    PRIMARY KEY (reminder_id, blocked_by_id),
+    FOREIGN KEY (reminder_id) REFERENCES reminders(id),
+    FOREIGN KEY (blocked_by_id) REFERENCES reminders(id)
+);
+
+CREATE INDEX idx_reminder_dependencies_blocked_by_id ON reminder_dependencies(blocked_by_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reminder_dependencies_blocked_by_id;
+DROP TABLE reminder_dependencies;
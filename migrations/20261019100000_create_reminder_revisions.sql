@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE reminder_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    reminder_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    actor_id INTEGER,
+    changes TEXT NOT NULL DEFAULT '{}',
+    before TEXT,
+    after TEXT,
+    created_at DATETIME,
+    FOREIGN KEY (reminder_id) REFERENCES reminders(id),
+    FOREIGN KEY (actor_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_reminder_revisions_reminder_id ON reminder_revisions(reminder_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reminder_revisions_reminder_id;
+DROP TABLE reminder_revisions;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE reminders ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE categories ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE categories DROP COLUMN version;
+ALTER TABLE reminders DROP COLUMN version;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE idempotency_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    content_type TEXT NOT NULL DEFAULT '',
+    body BLOB,
+    created_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE UNIQUE INDEX idx_idempotency_keys_user_key ON idempotency_keys(user_id, key);
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_idempotency_keys_created_at;
+DROP INDEX IF EXISTS idx_idempotency_keys_user_key;
+DROP TABLE idempotency_keys;
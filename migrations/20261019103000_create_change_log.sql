@@ -0,0 +1,277 @@
+-- +goose Up
+-- change_log records every change to the entities clients sync, one row per
+-- write. Its id is the sync token, and fields lists the columns an update
+-- changed so conflicting offline edits can be resolved field by field.
+CREATE TABLE change_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    entity_type TEXT NOT NULL,
+    entity_id INTEGER NOT NULL,
+    operation TEXT NOT NULL CHECK(operation IN ('create', 'update', 'delete')),
+    fields TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE INDEX idx_change_log_user_id ON change_log(user_id, id);
+CREATE INDEX idx_change_log_entity ON change_log(entity_type, entity_id, id);
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminders_insert AFTER INSERT ON reminders
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES (NEW.user_id, 'reminder', NEW.id, 'create');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminders_update AFTER UPDATE ON reminders
+WHEN OLD.title IS NOT NEW.title
+    OR OLD.description IS NOT NEW.description
+    OR OLD.category_id IS NOT NEW.category_id
+    OR OLD.due_date IS NOT NEW.due_date
+    OR OLD.priority IS NOT NEW.priority
+    OR OLD.status IS NOT NEW.status
+    OR OLD.is_recurring IS NOT NEW.is_recurring
+    OR OLD.recurring_pattern IS NOT NEW.recurring_pattern
+    OR OLD.auto_complete IS NOT NEW.auto_complete
+    OR OLD.completed_at IS NOT NEW.completed_at
+    OR OLD.archived_at IS NOT NEW.archived_at
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'reminder', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.title IS NOT NEW.title THEN 'title,' ELSE '' END ||
+            CASE WHEN OLD.description IS NOT NEW.description THEN 'description,' ELSE '' END ||
+            CASE WHEN OLD.category_id IS NOT NEW.category_id THEN 'category_id,' ELSE '' END ||
+            CASE WHEN OLD.due_date IS NOT NEW.due_date THEN 'due_date,' ELSE '' END ||
+            CASE WHEN OLD.priority IS NOT NEW.priority THEN 'priority,' ELSE '' END ||
+            CASE WHEN OLD.status IS NOT NEW.status THEN 'status,' ELSE '' END ||
+            CASE WHEN OLD.is_recurring IS NOT NEW.is_recurring THEN 'is_recurring,' ELSE '' END ||
+            CASE WHEN OLD.recurring_pattern IS NOT NEW.recurring_pattern THEN 'recurring_pattern,' ELSE '' END ||
+            CASE WHEN OLD.auto_complete IS NOT NEW.auto_complete THEN 'auto_complete,' ELSE '' END ||
+            CASE WHEN OLD.completed_at IS NOT NEW.completed_at THEN 'completed_at,' ELSE '' END ||
+            CASE WHEN OLD.archived_at IS NOT NEW.archived_at THEN 'archived_at,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminders_delete AFTER DELETE ON reminders
+WHEN OLD.deleted_at IS NULL
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES (OLD.user_id, 'reminder', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_categories_insert AFTER INSERT ON categories
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES (NEW.user_id, 'category', NEW.id, 'create');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_categories_update AFTER UPDATE ON categories
+WHEN OLD.name IS NOT NEW.name
+    OR OLD.color IS NOT NEW.color
+    OR OLD.icon IS NOT NEW.icon
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'category', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.name IS NOT NEW.name THEN 'name,' ELSE '' END ||
+            CASE WHEN OLD.color IS NOT NEW.color THEN 'color,' ELSE '' END ||
+            CASE WHEN OLD.icon IS NOT NEW.icon THEN 'icon,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_categories_delete AFTER DELETE ON categories
+WHEN OLD.deleted_at IS NULL
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES (OLD.user_id, 'category', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminder_items_insert AFTER INSERT ON reminder_items
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES ((SELECT user_id FROM reminders WHERE id = NEW.reminder_id), 'item', NEW.id, 'create');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminder_items_update AFTER UPDATE ON reminder_items
+WHEN OLD.text IS NOT NEW.text
+    OR OLD.done IS NOT NEW.done
+    OR OLD.position IS NOT NEW.position
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        (SELECT user_id FROM reminders WHERE id = NEW.reminder_id), 'item', NEW.id,
+        'update',
+        rtrim(
+            CASE WHEN OLD.text IS NOT NEW.text THEN 'text,' ELSE '' END ||
+            CASE WHEN OLD.done IS NOT NEW.done THEN 'done,' ELSE '' END ||
+            CASE WHEN OLD.position IS NOT NEW.position THEN 'position,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminder_items_delete AFTER DELETE ON reminder_items
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES ((SELECT user_id FROM reminders WHERE id = OLD.reminder_id), 'item', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminder_notes_insert AFTER INSERT ON reminder_notes
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES ((SELECT user_id FROM reminders WHERE id = NEW.reminder_id), 'note', NEW.id, 'create');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminder_notes_update AFTER UPDATE ON reminder_notes
+WHEN OLD.body IS NOT NEW.body
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        (SELECT user_id FROM reminders WHERE id = NEW.reminder_id), 'note', NEW.id,
+        'update',
+        rtrim(
+            CASE WHEN OLD.body IS NOT NEW.body THEN 'body,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminder_notes_delete AFTER DELETE ON reminder_notes
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES ((SELECT user_id FROM reminders WHERE id = OLD.reminder_id), 'note', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_attachments_insert AFTER INSERT ON attachments
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES (NEW.user_id, 'attachment', NEW.id, 'create');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_attachments_delete AFTER DELETE ON attachments
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES (OLD.user_id, 'attachment', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_tags_insert AFTER INSERT ON tags
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES (NEW.user_id, 'tag', NEW.id, 'create');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_tags_update AFTER UPDATE ON tags
+WHEN OLD.name IS NOT NEW.name
+    OR OLD.color IS NOT NEW.color
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'tag', NEW.id,
+        'update',
+        rtrim(
+            CASE WHEN OLD.name IS NOT NEW.name THEN 'name,' ELSE '' END ||
+            CASE WHEN OLD.color IS NOT NEW.color THEN 'color,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_tags_delete AFTER DELETE ON tags
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+    VALUES (OLD.user_id, 'tag', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- Existing rows are logged as created so a first sync returns everything
+INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+SELECT user_id, 'reminder', id, 'create' FROM reminders WHERE deleted_at IS NULL ORDER BY id;
+
+INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+SELECT user_id, 'category', id, 'create' FROM categories WHERE deleted_at IS NULL ORDER BY id;
+
+INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+SELECT (SELECT user_id FROM reminders WHERE id = reminder_items.reminder_id), 'item', id, 'create' FROM reminder_items ORDER BY id;
+
+INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+SELECT (SELECT user_id FROM reminders WHERE id = reminder_notes.reminder_id), 'note', id, 'create' FROM reminder_notes ORDER BY id;
+
+INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+SELECT user_id, 'attachment', id, 'create' FROM attachments ORDER BY id;
+
+INSERT INTO change_log (user_id, entity_type, entity_id, operation)
+SELECT user_id, 'tag', id, 'create' FROM tags ORDER BY id;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS change_log_tags_delete;
+DROP TRIGGER IF EXISTS change_log_tags_update;
+DROP TRIGGER IF EXISTS change_log_tags_insert;
+DROP TRIGGER IF EXISTS change_log_attachments_delete;
+DROP TRIGGER IF EXISTS change_log_attachments_insert;
+DROP TRIGGER IF EXISTS change_log_reminder_notes_delete;
+DROP TRIGGER IF EXISTS change_log_reminder_notes_update;
+DROP TRIGGER IF EXISTS change_log_reminder_notes_insert;
+DROP TRIGGER IF EXISTS change_log_reminder_items_delete;
+DROP TRIGGER IF EXISTS change_log_reminder_items_update;
+DROP TRIGGER IF EXISTS change_log_reminder_items_insert;
+DROP TRIGGER IF EXISTS change_log_categories_delete;
+DROP TRIGGER IF EXISTS change_log_categories_update;
+DROP TRIGGER IF EXISTS change_log_categories_insert;
+DROP TRIGGER IF EXISTS change_log_reminders_delete;
+DROP TRIGGER IF EXISTS change_log_reminders_update;
+DROP TRIGGER IF EXISTS change_log_reminders_insert;
+DROP INDEX IF EXISTS idx_change_log_entity;
+DROP INDEX IF EXISTS idx_change_log_user_id;
+DROP TABLE change_log;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE reminders ADD COLUMN position TEXT NOT NULL DEFAULT '';
+
+-- Keep the current due date order within each category. The version is
+-- bumped so synced clients pick up the new positions.
+UPDATE reminders SET
+    position = (
+        SELECT 'V' || printf('%05d', ranked.rn) || '1'
+        FROM (
+            SELECT id, ROW_NUMBER() OVER (PARTITION BY category_id ORDER BY due_date, id) AS rn
+            FROM reminders
+        ) ranked
+        WHERE ranked.id = reminders.id
+    ),
+    version = version + 1;
+
+CREATE INDEX idx_reminders_category_position ON reminders(category_id, position);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reminders_category_position;
+ALTER TABLE reminders DROP COLUMN position;
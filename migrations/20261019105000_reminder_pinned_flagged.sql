@@ -0,0 +1,103 @@
+-- +goose Up
+ALTER TABLE reminders ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE reminders ADD COLUMN flagged BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_reminders_user_flagged ON reminders(user_id, flagged);
+
+-- Record pinned and flagged changes field by field for sync
+DROP TRIGGER IF EXISTS change_log_reminders_update;
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminders_update AFTER UPDATE ON reminders
+WHEN OLD.title IS NOT NEW.title
+    OR OLD.description IS NOT NEW.description
+    OR OLD.category_id IS NOT NEW.category_id
+    OR OLD.due_date IS NOT NEW.due_date
+    OR OLD.priority IS NOT NEW.priority
+    OR OLD.status IS NOT NEW.status
+    OR OLD.is_recurring IS NOT NEW.is_recurring
+    OR OLD.recurring_pattern IS NOT NEW.recurring_pattern
+    OR OLD.auto_complete IS NOT NEW.auto_complete
+    OR OLD.pinned IS NOT NEW.pinned
+    OR OLD.flagged IS NOT NEW.flagged
+    OR OLD.completed_at IS NOT NEW.completed_at
+    OR OLD.archived_at IS NOT NEW.archived_at
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'reminder', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.title IS NOT NEW.title THEN 'title,' ELSE '' END ||
+            CASE WHEN OLD.description IS NOT NEW.description THEN 'description,' ELSE '' END ||
+            CASE WHEN OLD.category_id IS NOT NEW.category_id THEN 'category_id,' ELSE '' END ||
+            CASE WHEN OLD.due_date IS NOT NEW.due_date THEN 'due_date,' ELSE '' END ||
+            CASE WHEN OLD.priority IS NOT NEW.priority THEN 'priority,' ELSE '' END ||
+            CASE WHEN OLD.status IS NOT NEW.status THEN 'status,' ELSE '' END ||
+            CASE WHEN OLD.is_recurring IS NOT NEW.is_recurring THEN 'is_recurring,' ELSE '' END ||
+            CASE WHEN OLD.recurring_pattern IS NOT NEW.recurring_pattern THEN 'recurring_pattern,' ELSE '' END ||
+            CASE WHEN OLD.auto_complete IS NOT NEW.auto_complete THEN 'auto_complete,' ELSE '' END ||
+            CASE WHEN OLD.pinned IS NOT NEW.pinned THEN 'pinned,' ELSE '' END ||
+            CASE WHEN OLD.flagged IS NOT NEW.flagged THEN 'flagged,' ELSE '' END ||
+            CASE WHEN OLD.completed_at IS NOT NEW.completed_at THEN 'completed_at,' ELSE '' END ||
+            CASE WHEN OLD.archived_at IS NOT NEW.archived_at THEN 'archived_at,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS change_log_reminders_update;
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminders_update AFTER UPDATE ON reminders
+WHEN OLD.title IS NOT NEW.title
+    OR OLD.description IS NOT NEW.description
+    OR OLD.category_id IS NOT NEW.category_id
+    OR OLD.due_date IS NOT NEW.due_date
+    OR OLD.priority IS NOT NEW.priority
+    OR OLD.status IS NOT NEW.status
+    OR OLD.is_recurring IS NOT NEW.is_recurring
+    OR OLD.recurring_pattern IS NOT NEW.recurring_pattern
+    OR OLD.auto_complete IS NOT NEW.auto_complete
+    OR OLD.completed_at IS NOT NEW.completed_at
+    OR OLD.archived_at IS NOT NEW.archived_at
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'reminder', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.title IS NOT NEW.title THEN 'title,' ELSE '' END ||
+            CASE WHEN OLD.description IS NOT NEW.description THEN 'description,' ELSE '' END ||
+            CASE WHEN OLD.category_id IS NOT NEW.category_id THEN 'category_id,' ELSE '' END ||
+            CASE WHEN OLD.due_date IS NOT NEW.due_date THEN 'due_date,' ELSE '' END ||
+            CASE WHEN OLD.priority IS NOT NEW.priority THEN 'priority,' ELSE '' END ||
+            CASE WHEN OLD.status IS NOT NEW.status THEN 'status,' ELSE '' END ||
+            CASE WHEN OLD.is_recurring IS NOT NEW.is_recurring THEN 'is_recurring,' ELSE '' END ||
+            CASE WHEN OLD.recurring_pattern IS NOT NEW.recurring_pattern THEN 'recurring_pattern,' ELSE '' END ||
+            CASE WHEN OLD.auto_complete IS NOT NEW.auto_complete THEN 'auto_complete,' ELSE '' END ||
+            CASE WHEN OLD.completed_at IS NOT NEW.completed_at THEN 'completed_at,' ELSE '' END ||
+            CASE WHEN OLD.archived_at IS NOT NEW.archived_at THEN 'archived_at,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_reminders_user_flagged;
+ALTER TABLE reminders DROP COLUMN flagged;
+ALTER TABLE reminders DROP COLUMN pinned;
@@ -0,0 +1,129 @@
+-- +goose Up
+ALTER TABLE reminders ADD COLUMN start_date DATE;
+ALTER TABLE reminders ADD COLUMN estimated_minutes INTEGER;
+
+-- A time entry without ended_at is a running timer. Each user can only have
+-- one running at a time.
+CREATE TABLE time_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    reminder_id INTEGER NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME,
+    duration_seconds INTEGER NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_at DATETIME,
+    updated_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (reminder_id) REFERENCES reminders(id)
+);
+
+CREATE INDEX idx_time_entries_reminder_id ON time_entries(reminder_id);
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries(user_id) WHERE ended_at IS NULL;
+
+DROP TRIGGER IF EXISTS change_log_reminders_update;
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminders_update AFTER UPDATE ON reminders
+WHEN OLD.title IS NOT NEW.title
+    OR OLD.description IS NOT NEW.description
+    OR OLD.category_id IS NOT NEW.category_id
+    OR OLD.due_date IS NOT NEW.due_date
+    OR OLD.start_date IS NOT NEW.start_date
+    OR OLD.estimated_minutes IS NOT NEW.estimated_minutes
+    OR OLD.priority IS NOT NEW.priority
+    OR OLD.status IS NOT NEW.status
+    OR OLD.is_recurring IS NOT NEW.is_recurring
+    OR OLD.recurring_pattern IS NOT NEW.recurring_pattern
+    OR OLD.auto_complete IS NOT NEW.auto_complete
+    OR OLD.pinned IS NOT NEW.pinned
+    OR OLD.flagged IS NOT NEW.flagged
+    OR OLD.completed_at IS NOT NEW.completed_at
+    OR OLD.archived_at IS NOT NEW.archived_at
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'reminder', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.title IS NOT NEW.title THEN 'title,' ELSE '' END ||
+            CASE WHEN OLD.description IS NOT NEW.description THEN 'description,' ELSE '' END ||
+            CASE WHEN OLD.category_id IS NOT NEW.category_id THEN 'category_id,' ELSE '' END ||
+            CASE WHEN OLD.due_date IS NOT NEW.due_date THEN 'due_date,' ELSE '' END ||
+            CASE WHEN OLD.start_date IS NOT NEW.start_date THEN 'start_date,' ELSE '' END ||
+            CASE WHEN OLD.estimated_minutes IS NOT NEW.estimated_minutes THEN 'estimated_minutes,' ELSE '' END ||
+            CASE WHEN OLD.priority IS NOT NEW.priority THEN 'priority,' ELSE '' END ||
+            CASE WHEN OLD.status IS NOT NEW.status THEN 'status,' ELSE '' END ||
+            CASE WHEN OLD.is_recurring IS NOT NEW.is_recurring THEN 'is_recurring,' ELSE '' END ||
+            CASE WHEN OLD.recurring_pattern IS NOT NEW.recurring_pattern THEN 'recurring_pattern,' ELSE '' END ||
+            CASE WHEN OLD.auto_complete IS NOT NEW.auto_complete THEN 'auto_complete,' ELSE '' END ||
+            CASE WHEN OLD.pinned IS NOT NEW.pinned THEN 'pinned,' ELSE '' END ||
+            CASE WHEN OLD.flagged IS NOT NEW.flagged THEN 'flagged,' ELSE '' END ||
+            CASE WHEN OLD.completed_at IS NOT NEW.completed_at THEN 'completed_at,' ELSE '' END ||
+            CASE WHEN OLD.archived_at IS NOT NEW.archived_at THEN 'archived_at,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS change_log_reminders_update;
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_reminders_update AFTER UPDATE ON reminders
+WHEN OLD.title IS NOT NEW.title
+    OR OLD.description IS NOT NEW.description
+    OR OLD.category_id IS NOT NEW.category_id
+    OR OLD.due_date IS NOT NEW.due_date
+    OR OLD.priority IS NOT NEW.priority
+    OR OLD.status IS NOT NEW.status
+    OR OLD.is_recurring IS NOT NEW.is_recurring
+    OR OLD.recurring_pattern IS NOT NEW.recurring_pattern
+    OR OLD.auto_complete IS NOT NEW.auto_complete
+    OR OLD.pinned IS NOT NEW.pinned
+    OR OLD.flagged IS NOT NEW.flagged
+    OR OLD.completed_at IS NOT NEW.completed_at
+    OR OLD.archived_at IS NOT NEW.archived_at
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'reminder', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.title IS NOT NEW.title THEN 'title,' ELSE '' END ||
+            CASE WHEN OLD.description IS NOT NEW.description THEN 'description,' ELSE '' END ||
+            CASE WHEN OLD.category_id IS NOT NEW.category_id THEN 'category_id,' ELSE '' END ||
+            CASE WHEN OLD.due_date IS NOT NEW.due_date THEN 'due_date,' ELSE '' END ||
+            CASE WHEN OLD.priority IS NOT NEW.priority THEN 'priority,' ELSE '' END ||
+            CASE WHEN OLD.status IS NOT NEW.status THEN 'status,' ELSE '' END ||
+            CASE WHEN OLD.is_recurring IS NOT NEW.is_recurring THEN 'is_recurring,' ELSE '' END ||
+            CASE WHEN OLD.recurring_pattern IS NOT NEW.recurring_pattern THEN 'recurring_pattern,' ELSE '' END ||
+            CASE WHEN OLD.auto_complete IS NOT NEW.auto_complete THEN 'auto_complete,' ELSE '' END ||
+            CASE WHEN OLD.pinned IS NOT NEW.pinned THEN 'pinned,' ELSE '' END ||
+            CASE WHEN OLD.flagged IS NOT NEW.flagged THEN 'flagged,' ELSE '' END ||
+            CASE WHEN OLD.completed_at IS NOT NEW.completed_at THEN 'completed_at,' ELSE '' END ||
+            CASE WHEN OLD.archived_at IS NOT NEW.archived_at THEN 'archived_at,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_time_entries_running;
+DROP INDEX IF EXISTS idx_time_entries_reminder_id;
+DROP TABLE time_entries;
+ALTER TABLE reminders DROP COLUMN estimated_minutes;
+ALTER TABLE reminders DROP COLUMN start_date;
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"reminder-server/internal/seed"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upBackfillUserCategories, downBackfillUserCategories)
+}
+
+// upBackfillUserCategories gives every user the seed categories they don't
+// have yet, since categories used to be seeded for user 1 only. Ones in the
+// trash count, so deleted defaults aren't brought back. The seed set is the
+// one new users get, which SEED_CATEGORIES_FILE can replace.
+func upBackfillUserCategories(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM users ORDER BY id")
+
+	if err != nil {
+		return err
+	}
+
+	var userIDs []int64
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+
+		userIDs = append(userIDs, id)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		for _, category := range seed.DefaultCategories() {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO categories (name, color, icon, user_id, created_at)
+				SELECT ?, ?, ?, ?, CURRENT_TIMESTAMP
+				WHERE NOT EXISTS (
+					SELECT 1 FROM categories WHERE user_id = ? AND lower(name) = lower(?)
+				)`,
+				category.Name, category.Color, category.Icon, userID, userID, category.Name,
+			)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// downBackfillUserCategories keeps the backfilled categories since reminders
+// may use them by now.
+func downBackfillUserCategories(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
@@ -0,0 +1,32 @@
+-- +goose Up
+-- Categories created through the API used to get no owner. Hand each one to
+-- the user whose reminders use it.
+UPDATE categories SET
+    user_id = (
+        SELECT reminders.user_id FROM reminders
+        WHERE reminders.category_id = categories.id
+        ORDER BY reminders.id
+        LIMIT 1
+    ),
+    version = version + 1
+WHERE (user_id IS NULL OR user_id = 0)
+    AND EXISTS (SELECT 1 FROM reminders WHERE reminders.category_id = categories.id);
+
+-- Names become unique per user regardless of case. Later duplicates get
+-- their id appended so nothing is lost.
+UPDATE categories SET
+    name = name || ' (' || id || ')',
+    version = version + 1
+WHERE deleted_at IS NULL
+    AND EXISTS (
+        SELECT 1 FROM categories AS other
+        WHERE other.user_id = categories.user_id
+            AND lower(other.name) = lower(categories.name)
+            AND other.deleted_at IS NULL
+            AND other.id < categories.id
+    );
+
+CREATE UNIQUE INDEX idx_categories_user_name ON categories(user_id, lower(name)) WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_categories_user_name;
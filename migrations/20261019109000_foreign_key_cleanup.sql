@@ -0,0 +1,67 @@
+-- +goose Up
+-- Foreign keys are enforced on every connection from now on. Clean up the
+-- rows older code could leave pointing at nothing, starting with whatever
+-- hangs off reminders and tags that no longer exist.
+DELETE FROM reminder_note_revisions WHERE note_id NOT IN (SELECT id FROM reminder_notes)
+    OR note_id IN (SELECT id FROM reminder_notes WHERE reminder_id NOT IN (SELECT id FROM reminders));
+DELETE FROM reminder_notes WHERE reminder_id NOT IN (SELECT id FROM reminders);
+DELETE FROM reminder_items WHERE reminder_id NOT IN (SELECT id FROM reminders);
+DELETE FROM reminder_tags WHERE reminder_id NOT IN (SELECT id FROM reminders) OR tag_id NOT IN (SELECT id FROM tags);
+DELETE FROM attachments WHERE reminder_id NOT IN (SELECT id FROM reminders);
+DELETE FROM reminder_revisions WHERE reminder_id NOT IN (SELECT id FROM reminders);
+DELETE FROM time_entries WHERE reminder_id NOT IN (SELECT id FROM reminders);
+DELETE FROM reminder_dependencies WHERE reminder_id NOT IN (SELECT id FROM reminders)
+    OR blocked_by_id NOT IN (SELECT id FROM reminders);
+
+-- Reminders and templates whose category is gone or belongs to someone else
+-- move to an Uncategorized category of their owner, created where needed.
+INSERT INTO categories (name, color, icon, user_id, created_at)
+SELECT DISTINCT 'Uncategorized', '', '', owners.user_id, CURRENT_TIMESTAMP
+FROM (
+    SELECT user_id FROM reminders
+    WHERE category_id IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM categories WHERE categories.id = reminders.category_id AND categories.user_id = reminders.user_id
+    )
+    UNION
+    SELECT user_id FROM reminder_templates
+    WHERE NOT EXISTS (
+        SELECT 1 FROM categories WHERE categories.id = reminder_templates.category_id AND categories.user_id = reminder_templates.user_id
+    )
+) owners
+WHERE owners.user_id IN (SELECT id FROM users)
+    AND NOT EXISTS (
+        SELECT 1 FROM categories
+        WHERE categories.user_id = owners.user_id AND lower(categories.name) = 'uncategorized' AND categories.deleted_at IS NULL
+    );
+
+UPDATE reminders SET
+    category_id = (
+        SELECT MIN(id) FROM categories
+        WHERE categories.user_id = reminders.user_id AND lower(categories.name) = 'uncategorized' AND categories.deleted_at IS NULL
+    ),
+    version = version + 1
+WHERE category_id IS NOT NULL
+    AND user_id IN (SELECT id FROM users)
+    AND NOT EXISTS (
+        SELECT 1 FROM categories WHERE categories.id = reminders.category_id AND categories.user_id = reminders.user_id
+    );
+
+UPDATE reminder_templates SET
+    category_id = (
+        SELECT MIN(id) FROM categories
+        WHERE categories.user_id = reminder_templates.user_id AND lower(categories.name) = 'uncategorized' AND categories.deleted_at IS NULL
+    )
+WHERE user_id IN (SELECT id FROM users)
+    AND NOT EXISTS (
+        SELECT 1 FROM categories WHERE categories.id = reminder_templates.category_id AND categories.user_id = reminder_templates.user_id
+    );
+
+-- Categories without an owner can't be reached through the API anymore
+DELETE FROM categories
+WHERE (user_id IS NULL OR user_id NOT IN (SELECT id FROM users))
+    AND id NOT IN (SELECT category_id FROM reminders WHERE category_id IS NOT NULL)
+    AND id NOT IN (SELECT category_id FROM reminder_templates);
+
+-- +goose Down
+-- Removed rows pointed at nothing, so there is nothing to bring back
+SELECT 1;
@@ -0,0 +1,66 @@
+-- +goose Up
+ALTER TABLE categories ADD COLUMN parent_id INTEGER REFERENCES categories(id);
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+DROP TRIGGER IF EXISTS change_log_categories_update;
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_categories_update AFTER UPDATE ON categories
+WHEN OLD.name IS NOT NEW.name
+    OR OLD.color IS NOT NEW.color
+    OR OLD.icon IS NOT NEW.icon
+    OR OLD.parent_id IS NOT NEW.parent_id
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'category', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.name IS NOT NEW.name THEN 'name,' ELSE '' END ||
+            CASE WHEN OLD.color IS NOT NEW.color THEN 'color,' ELSE '' END ||
+            CASE WHEN OLD.icon IS NOT NEW.icon THEN 'icon,' ELSE '' END ||
+            CASE WHEN OLD.parent_id IS NOT NEW.parent_id THEN 'parent_id,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS change_log_categories_update;
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_categories_update AFTER UPDATE ON categories
+WHEN OLD.name IS NOT NEW.name
+    OR OLD.color IS NOT NEW.color
+    OR OLD.icon IS NOT NEW.icon
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'category', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.name IS NOT NEW.name THEN 'name,' ELSE '' END ||
+            CASE WHEN OLD.color IS NOT NEW.color THEN 'color,' ELSE '' END ||
+            CASE WHEN OLD.icon IS NOT NEW.icon THEN 'icon,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_categories_parent_id;
+ALTER TABLE categories DROP COLUMN parent_id;
@@ -0,0 +1,81 @@
+-- +goose Up
+-- Defaults fill in what a new reminder in the category leaves out. A
+-- wip_limit of 0 means the category has no limit.
+ALTER TABLE categories ADD COLUMN default_priority TEXT NOT NULL DEFAULT '' CHECK(default_priority IN ('', 'high', 'medium', 'low'));
+ALTER TABLE categories ADD COLUMN default_due_time TEXT NOT NULL DEFAULT '';
+ALTER TABLE categories ADD COLUMN default_tag_ids TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE categories ADD COLUMN wip_limit INTEGER NOT NULL DEFAULT 0;
+
+DROP TRIGGER IF EXISTS change_log_categories_update;
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_categories_update AFTER UPDATE ON categories
+WHEN OLD.name IS NOT NEW.name
+    OR OLD.color IS NOT NEW.color
+    OR OLD.icon IS NOT NEW.icon
+    OR OLD.parent_id IS NOT NEW.parent_id
+    OR OLD.default_priority IS NOT NEW.default_priority
+    OR OLD.default_due_time IS NOT NEW.default_due_time
+    OR OLD.default_tag_ids IS NOT NEW.default_tag_ids
+    OR OLD.wip_limit IS NOT NEW.wip_limit
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'category', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.name IS NOT NEW.name THEN 'name,' ELSE '' END ||
+            CASE WHEN OLD.color IS NOT NEW.color THEN 'color,' ELSE '' END ||
+            CASE WHEN OLD.icon IS NOT NEW.icon THEN 'icon,' ELSE '' END ||
+            CASE WHEN OLD.parent_id IS NOT NEW.parent_id THEN 'parent_id,' ELSE '' END ||
+            CASE WHEN OLD.default_priority IS NOT NEW.default_priority THEN 'default_priority,' ELSE '' END ||
+            CASE WHEN OLD.default_due_time IS NOT NEW.default_due_time THEN 'default_due_time,' ELSE '' END ||
+            CASE WHEN OLD.default_tag_ids IS NOT NEW.default_tag_ids THEN 'default_tag_ids,' ELSE '' END ||
+            CASE WHEN OLD.wip_limit IS NOT NEW.wip_limit THEN 'wip_limit,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS change_log_categories_update;
+
+-- +goose StatementBegin
+CREATE TRIGGER change_log_categories_update AFTER UPDATE ON categories
+WHEN OLD.name IS NOT NEW.name
+    OR OLD.color IS NOT NEW.color
+    OR OLD.icon IS NOT NEW.icon
+    OR OLD.parent_id IS NOT NEW.parent_id
+    OR OLD.version IS NOT NEW.version
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO change_log (user_id, entity_type, entity_id, operation, fields)
+    VALUES (
+        NEW.user_id, 'category', NEW.id,
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'create'
+            ELSE 'update'
+        END,
+        rtrim(
+            CASE WHEN OLD.name IS NOT NEW.name THEN 'name,' ELSE '' END ||
+            CASE WHEN OLD.color IS NOT NEW.color THEN 'color,' ELSE '' END ||
+            CASE WHEN OLD.icon IS NOT NEW.icon THEN 'icon,' ELSE '' END ||
+            CASE WHEN OLD.parent_id IS NOT NEW.parent_id THEN 'parent_id,' ELSE '' END,
+            ','
+        )
+    );
+END;
+-- +goose StatementEnd
+
+ALTER TABLE categories DROP COLUMN wip_limit;
+ALTER TABLE categories DROP COLUMN default_tag_ids;
+ALTER TABLE categories DROP COLUMN default_due_time;
+ALTER TABLE categories DROP COLUMN default_priority;
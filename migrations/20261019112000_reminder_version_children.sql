@@ -0,0 +1,81 @@
+-- +goose Up
+-- A reminder's ETag stands for its progress, blocked state and notes as
+-- well, so changes to its items, notes and dependencies bump its version,
+-- and so does a blocker being completed, reopened, trashed or restored.
+
+-- +goose StatementBegin
+CREATE TRIGGER reminder_items_version_insert AFTER INSERT ON reminder_items
+BEGIN
+    UPDATE reminders SET version = version + 1 WHERE id = NEW.reminder_id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER reminder_items_version_update AFTER UPDATE ON reminder_items
+BEGIN
+    UPDATE reminders SET version = version + 1 WHERE id IN (OLD.reminder_id, NEW.reminder_id);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER reminder_items_version_delete AFTER DELETE ON reminder_items
+BEGIN
+    UPDATE reminders SET version = version + 1 WHERE id = OLD.reminder_id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER reminder_notes_version_insert AFTER INSERT ON reminder_notes
+BEGIN
+    UPDATE reminders SET version = version + 1 WHERE id = NEW.reminder_id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER reminder_notes_version_update AFTER UPDATE ON reminder_notes
+BEGIN
+    UPDATE reminders SET version = version + 1 WHERE id IN (OLD.reminder_id, NEW.reminder_id);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER reminder_notes_version_delete AFTER DELETE ON reminder_notes
+BEGIN
+    UPDATE reminders SET version = version + 1 WHERE id = OLD.reminder_id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER reminder_dependencies_version_insert AFTER INSERT ON reminder_dependencies
+BEGIN
+    UPDATE reminders SET version = version + 1 WHERE id = NEW.reminder_id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER reminder_dependencies_version_delete AFTER DELETE ON reminder_dependencies
+BEGIN
+    UPDATE reminders SET version = version + 1 WHERE id = OLD.reminder_id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER reminders_version_dependents AFTER UPDATE ON reminders
+WHEN OLD.status IS NOT NEW.status
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    UPDATE reminders SET version = version + 1
+    WHERE id IN (SELECT reminder_id FROM reminder_dependencies WHERE blocked_by_id = NEW.id);
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS reminders_version_dependents;
+DROP TRIGGER IF EXISTS reminder_dependencies_version_delete;
+DROP TRIGGER IF EXISTS reminder_dependencies_version_insert;
+DROP TRIGGER IF EXISTS reminder_notes_version_delete;
+DROP TRIGGER IF EXISTS reminder_notes_version_update;
+DROP TRIGGER IF EXISTS reminder_notes_version_insert;
+DROP TRIGGER IF EXISTS reminder_items_version_delete;
+DROP TRIGGER IF EXISTS reminder_items_version_update;
+DROP TRIGGER IF EXISTS reminder_items_version_insert;
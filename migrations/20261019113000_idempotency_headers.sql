@@ -0,0 +1,6 @@
+-- +goose Up
+-- Replays send back the ETag and Location of the stored response too
+ALTER TABLE idempotency_keys ADD COLUMN headers TEXT NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE idempotency_keys DROP COLUMN headers;
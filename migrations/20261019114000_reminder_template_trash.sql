@@ -0,0 +1,11 @@
+-- +goose Up
+-- Templates go to the trash with their category on a cascading delete, so
+-- restoring the category brings them back.
+ALTER TABLE reminder_templates ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX idx_reminder_templates_deleted_at ON reminder_templates(deleted_at);
+
+-- +goose Down
+DELETE FROM reminder_templates WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_reminder_templates_deleted_at;
+ALTER TABLE reminder_templates DROP COLUMN deleted_at;